@@ -0,0 +1,147 @@
+package api
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen est retournée quand un endpoint est temporairement désactivé
+var ErrCircuitOpen = errors.New("circuit ouvert: endpoint temporairement désactivé")
+
+// BreakerState représente l'état du disjoncteur d'un endpoint
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // Les requêtes passent normalement
+	BreakerOpen                         // Les requêtes sont refusées sans appel réseau
+	BreakerHalfOpen                     // Une requête d'essai est autorisée
+)
+
+// String retourne le nom lisible de l'état
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "fermé"
+	case BreakerOpen:
+		return "ouvert"
+	case BreakerHalfOpen:
+		return "semi-ouvert"
+	default:
+		return "inconnu"
+	}
+}
+
+// BreakerSettings configure le disjoncteur de chaque endpoint
+type BreakerSettings struct {
+	FailureThreshold int           // Échecs consécutifs avant ouverture (0 = désactivé)
+	Cooldown         time.Duration // Durée d'ouverture avant une requête d'essai
+}
+
+// DefaultBreakerSettings est utilisé si aucune option ne le change
+var DefaultBreakerSettings = BreakerSettings{
+	FailureThreshold: 5,
+	Cooldown:         30 * time.Second,
+}
+
+// WithCircuitBreaker configure le disjoncteur de chaque endpoint
+func WithCircuitBreaker(settings BreakerSettings) Option {
+	return func(c *Client) {
+		c.breakerSettings = settings
+	}
+}
+
+// circuitBreaker coupe les appels vers un endpoint qui échoue en boucle
+type circuitBreaker struct {
+	mu       sync.Mutex
+	settings BreakerSettings
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	trial    bool // Une requête d'essai est en cours (état semi-ouvert)
+}
+
+// allow indique si une requête peut être envoyée
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.settings.FailureThreshold <= 0 {
+		return nil
+	}
+
+	switch b.currentState() {
+	case BreakerOpen:
+		return ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.trial = true
+	}
+	return nil
+}
+
+// success referme le disjoncteur
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.trial = false
+}
+
+// failure comptabilise un échec et ouvre le disjoncteur si le seuil est atteint
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.settings.FailureThreshold > 0 && (b.trial || b.failures >= b.settings.FailureThreshold) {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+	b.trial = false
+}
+
+// abort libère la requête d'essai sans conclure (requête annulée par l'appelant)
+func (b *circuitBreaker) abort() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// State retourne l'état actuel du disjoncteur
+func (b *circuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentState()
+}
+
+// currentState calcule l'état en tenant compte du délai de refroidissement
+func (b *circuitBreaker) currentState() BreakerState {
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.settings.Cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// breaker retourne le disjoncteur associé à un endpoint
+func (c *Client) breaker(endpoint string) *circuitBreaker {
+	c.breakersMu.Lock()
+	defer c.breakersMu.Unlock()
+
+	b, ok := c.breakers[endpoint]
+	if !ok {
+		b = &circuitBreaker{settings: c.breakerSettings}
+		c.breakers[endpoint] = b
+	}
+	return b
+}
+
+// BreakerState retourne l'état du disjoncteur d'un endpoint
+func (c *Client) BreakerState(endpoint string) BreakerState {
+	return c.breaker(endpoint).State()
+}
@@ -0,0 +1,362 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"groupie-tracker/internal/fsutil"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheMaxAge est la durée de validité d'une réponse sans ETag ni Last-Modified
+const DefaultCacheMaxAge = time.Hour
+
+// CacheStatus indique d'où viennent les dernières données d'un endpoint
+type CacheStatus struct {
+	FromCache   bool          // Les données viennent du disque
+	Revalidated bool          // Le serveur a confirmé les données (304 Not Modified)
+	Stale       bool          // Données périmées servies car le réseau est indisponible
+	Age         time.Duration // Âge des données depuis leur dernière validation
+}
+
+// String retourne une description courte ("cache, il y a 2 h")
+func (s CacheStatus) String() string {
+	switch {
+	case s.Stale:
+		return "cache périmé, " + formatAge(s.Age)
+	case s.FromCache:
+		return "cache, " + formatAge(s.Age)
+	default:
+		return "réseau"
+	}
+}
+
+// formatAge formate une durée sous la forme "il y a 5 min"
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "à l'instant"
+	case age < time.Hour:
+		return fmt.Sprintf("il y a %d min", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("il y a %d h", int(age.Hours()))
+	default:
+		return fmt.Sprintf("il y a %d j", int(age.Hours()/24))
+	}
+}
+
+// DefaultCacheDir retourne le dossier de cache de l'utilisateur pour l'application
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "groupie-tracker", "http"), nil
+}
+
+// WithCache active le cache disque des réponses dans dir.
+// maxAge s'applique aux réponses sans ETag ni Last-Modified.
+func WithCache(dir string, maxAge time.Duration) Option {
+	return func(c *Client) {
+		if dir == "" {
+			return
+		}
+		c.cache = &diskCache{dir: dir, maxAge: maxAge}
+	}
+}
+
+// diskCache stocke les réponses sur disque (un fichier de métadonnées et un fichier de contenu par URL)
+type diskCache struct {
+	dir    string
+	maxAge time.Duration
+}
+
+// cacheEntry représente une réponse mise en cache
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	ContentType  string    `json:"contentType,omitempty"`
+	StoredAt     time.Time `json:"storedAt"`
+
+	body []byte
+}
+
+// hasValidators indique si l'entrée peut être revalidée auprès du serveur
+func (e *cacheEntry) hasValidators() bool {
+	return e.ETag != "" || e.LastModified != ""
+}
+
+// response reconstruit une réponse HTTP à partir de l'entrée
+func (e *cacheEntry) response() *http.Response {
+	header := make(http.Header)
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+	}
+}
+
+// path retourne le chemin des fichiers associés à une URL
+func (d *diskCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:16]))
+}
+
+// load lit une entrée du cache (nil si absente ou illisible)
+func (d *diskCache) load(url string) *cacheEntry {
+	base := d.path(url)
+
+	meta, err := os.ReadFile(base + ".json")
+	if err != nil {
+		return nil
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(meta, &entry); err != nil || entry.URL != url {
+		return nil
+	}
+
+	entry.body, err = os.ReadFile(base + ".body")
+	if err != nil {
+		return nil
+	}
+
+	return &entry
+}
+
+// store enregistre une entrée sur disque
+func (d *diskCache) store(entry *cacheEntry) error {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return err
+	}
+
+	base := d.path(entry.URL)
+	if entry.body != nil {
+		if err := fsutil.WriteFileAtomic(base+".body", entry.body); err != nil {
+			return err
+		}
+	}
+
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(base+".json", meta)
+}
+
+// getCached sert un endpoint via le cache disque :
+//   - une entrée avec ETag/Last-Modified est revalidée par une requête conditionnelle ;
+//   - une entrée sans validateur est servie telle quelle tant qu'elle a moins de maxAge ;
+//   - si le réseau ou le serveur est indisponible, l'entrée est servie même périmée.
+func (c *Client) getCached(ctx context.Context, endpoint string) (*http.Response, error) {
+	url := c.baseURL + endpoint
+	entry := c.cache.load(url)
+
+	if entry != nil && !entry.hasValidators() && time.Since(entry.StoredAt) < c.cache.maxAge {
+		c.setCacheStatus(endpoint, CacheStatus{FromCache: true, Age: time.Since(entry.StoredAt)})
+		return entry.response(), nil
+	}
+
+	conditional := make(http.Header)
+	if entry != nil {
+		if entry.ETag != "" {
+			conditional.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			conditional.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := c.fetch(ctx, endpoint, conditional)
+	if err != nil {
+		if entry != nil && ctx.Err() == nil {
+			c.setCacheStatus(endpoint, CacheStatus{FromCache: true, Stale: true, Age: time.Since(entry.StoredAt)})
+			return entry.response(), nil
+		}
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		resp.Body.Close()
+		entry.StoredAt = time.Now()
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			entry.ETag = etag
+		}
+		if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+			entry.LastModified = lastModified
+		}
+		// Seules les métadonnées changent
+		body := entry.body
+		entry.body = nil
+		c.cache.store(entry)
+		entry.body = body
+
+		c.setCacheStatus(endpoint, CacheStatus{FromCache: true, Revalidated: true})
+		return entry.response(), nil
+
+	case resp.StatusCode == http.StatusOK:
+		fresh := &cacheEntry{
+			URL:          url,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			ContentType:  resp.Header.Get("Content-Type"),
+			StoredAt:     time.Now(),
+		}
+
+		// La réponse est enregistrée au fil de la lecture, une fois complète et valide
+		resp.Body = c.cache.cachingBody(resp.Body, fresh, c.maxResponseSize)
+
+		c.setCacheStatus(endpoint, CacheStatus{})
+		return resp, nil
+
+	case resp.StatusCode >= http.StatusInternalServerError && entry != nil:
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		c.setCacheStatus(endpoint, CacheStatus{FromCache: true, Stale: true, Age: time.Since(entry.StoredAt)})
+		return entry.response(), nil
+	}
+
+	c.setCacheStatus(endpoint, CacheStatus{})
+	return resp, nil
+}
+
+// cachingBody retourne body en copiant son contenu dans un fichier temporaire du cache.
+// Le fichier est renommé à la place de l'entrée une fois la lecture complète et le JSON valide ;
+// il est abandonné si la réponse dépasse limit octets ou si la lecture échoue.
+func (d *diskCache) cachingBody(body io.ReadCloser, entry *cacheEntry, limit int64) io.ReadCloser {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return body
+	}
+	tmp, err := os.CreateTemp(d.dir, filepath.Base(d.path(entry.URL))+".body.tmp*")
+	if err != nil {
+		// Un cache indisponible ne doit pas empêcher le chargement
+		return body
+	}
+	return &cachingBody{ReadCloser: body, cache: d, entry: entry, tmp: tmp, limit: limit}
+}
+
+// cachingBody copie le corps d'une réponse sur disque pendant sa lecture
+type cachingBody struct {
+	io.ReadCloser
+	cache   *diskCache
+	entry   *cacheEntry
+	tmp     *os.File // nil une fois la copie terminée ou abandonnée
+	written int64
+	limit   int64
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if b.tmp == nil {
+		return n, err
+	}
+
+	if n > 0 {
+		b.written += int64(n)
+		if b.limit > 0 && b.written > b.limit {
+			b.abandon()
+			return n, err
+		}
+		if _, werr := b.tmp.Write(p[:n]); werr != nil {
+			b.abandon()
+			return n, err
+		}
+	}
+
+	switch {
+	case err == io.EOF:
+		b.commit()
+	case err != nil:
+		b.abandon()
+	}
+	return n, err
+}
+
+func (b *cachingBody) Close() error {
+	// Un corps fermé avant la fin de la lecture n'est pas mis en cache
+	if b.tmp != nil {
+		b.abandon()
+	}
+	return b.ReadCloser.Close()
+}
+
+// commit installe le fichier temporaire comme contenu de l'entrée, puis ses métadonnées
+func (b *cachingBody) commit() {
+	tmp := b.tmp.Name()
+	err := b.tmp.Close()
+	b.tmp = nil
+	if err != nil || !validJSONFile(tmp) {
+		os.Remove(tmp)
+		return
+	}
+	if err := os.Rename(tmp, b.cache.path(b.entry.URL)+".body"); err != nil {
+		os.Remove(tmp)
+		return
+	}
+	// Le contenu est déjà en place : seules les métadonnées restent à écrire
+	b.cache.store(b.entry)
+}
+
+// abandon supprime le fichier temporaire
+func (b *cachingBody) abandon() {
+	tmp := b.tmp.Name()
+	b.tmp.Close()
+	b.tmp = nil
+	os.Remove(tmp)
+}
+
+// validJSONFile vérifie qu'un fichier contient une valeur JSON, sans le charger en mémoire
+func validJSONFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+	tokens, depth := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// Token ne signale pas un tableau ou un objet resté ouvert en fin de fichier
+			return err == io.EOF && tokens > 0 && depth == 0
+		}
+		tokens++
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '[', '{':
+				depth++
+			default:
+				depth--
+			}
+		}
+	}
+}
+
+// setCacheStatus mémorise l'origine des dernières données d'un endpoint
+func (c *Client) setCacheStatus(endpoint string, status CacheStatus) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.cacheStatus[endpoint] = status
+}
+
+// CacheStatus retourne l'origine des dernières données chargées pour un endpoint
+func (c *Client) CacheStatus(endpoint string) CacheStatus {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	return c.cacheStatus[endpoint]
+}
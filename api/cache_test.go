@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"groupie-tracker/fakeapi"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// statusRecorder mémorise les codes HTTP renvoyés par le faux serveur
+type statusRecorder struct {
+	http.Handler
+	mu       sync.Mutex
+	statuses []int
+}
+
+func (s *statusRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+	s.Handler.ServeHTTP(rec, r)
+	s.mu.Lock()
+	s.statuses = append(s.statuses, rec.status)
+	s.mu.Unlock()
+}
+
+func (s *statusRecorder) last() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.statuses) == 0 {
+		return 0
+	}
+	return s.statuses[len(s.statuses)-1]
+}
+
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func newCachedClient(t *testing.T, dir string, extra ...Option) (*Client, *statusRecorder) {
+	t.Helper()
+	recorder := &statusRecorder{Handler: fakeapi.New(nil, fakeapi.Options{})}
+	srv := httptest.NewServer(recorder)
+	t.Cleanup(srv.Close)
+	options := append([]Option{WithBaseURL(srv.URL + fakeapi.Prefix), WithRetry(NoRetry), WithCache(dir, DefaultCacheMaxAge)}, extra...)
+	return NewClient(options...), recorder
+}
+
+// cacheFiles retourne les fichiers présents dans le dossier de cache
+func cacheFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matches
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	dir := t.TempDir()
+	client, recorder := newCachedClient(t, dir)
+	ctx := context.Background()
+
+	steps := []struct {
+		name        string
+		wantStatus  int
+		wantCache   bool
+		revalidated bool
+	}{
+		{"premier chargement", http.StatusOK, false, false},
+		{"revalidation par ETag", http.StatusNotModified, true, true},
+		{"seconde revalidation", http.StatusNotModified, true, true},
+	}
+
+	for _, step := range steps {
+		artists, err := client.GetArtistsContext(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if len(artists) == 0 {
+			t.Fatalf("%s: aucun artiste", step.name)
+		}
+		if got := recorder.last(); got != step.wantStatus {
+			t.Errorf("%s: statut %d, attendu %d", step.name, got, step.wantStatus)
+		}
+		status := client.CacheStatus(EndpointArtists)
+		if status.FromCache != step.wantCache || status.Revalidated != step.revalidated {
+			t.Errorf("%s: statut du cache %+v", step.name, status)
+		}
+	}
+
+	// Un fichier de contenu et un de métadonnées, sans fichier temporaire restant
+	files := cacheFiles(t, dir)
+	if len(files) != 2 {
+		t.Errorf("fichiers du cache = %v, attendu .body et .json", files)
+	}
+}
+
+func TestCacheSkipsOversizedResponse(t *testing.T) {
+	dir := t.TempDir()
+	client, _ := newCachedClient(t, dir, WithMaxResponseSize(64))
+
+	_, err := client.GetArtistsContext(context.Background())
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("GetArtists() = %v, attendu %v", err, ErrResponseTooLarge)
+	}
+	if errors.Is(err, ErrDecode) {
+		t.Errorf("GetArtists() = %v, ne doit pas être une erreur de décodage", err)
+	}
+	if files := cacheFiles(t, dir); len(files) != 0 {
+		t.Errorf("fichiers du cache = %v, attendu aucun", files)
+	}
+}
+
+func TestValidJSONFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"objet", `{"id": 1}`, true},
+		{"tableau", `[1, 2, 3]`, true},
+		{"vide", ``, false},
+		{"tronqué", `[{"id": 1}`, false},
+		{"syntaxe", `{"id": }`, false},
+	}
+
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "body")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if got := validJSONFile(path); got != tt.want {
+				t.Errorf("validJSONFile(%q) = %v, attendu %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
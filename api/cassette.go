@@ -0,0 +1,276 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"groupie-tracker/internal/fsutil"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// CassetteVersion est la version du format des cassettes
+const CassetteVersion = 1
+
+// ErrUnrecorded est retournée en relecture pour une requête absente de la cassette
+var ErrUnrecorded = errors.New("requête absente de la cassette")
+
+// Interaction est un couple requête/réponse enregistré
+type Interaction struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Status     int         `json:"status,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 []byte      `json:"bodyBase64,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	RecordedAt time.Time   `json:"recordedAt"`
+}
+
+// Cassette contient toutes les interactions d'une session
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// interactionKey identifie une requête indépendamment de l'hôte (chemin et paramètres)
+func interactionKey(method string, req *http.Request) string {
+	return method + " " + req.URL.RequestURI()
+}
+
+// Recorder est un http.RoundTripper qui enregistre chaque échange dans une cassette.
+// Les interactions sont gardées en mémoire et écrites sur disque par Flush ou Close.
+type Recorder struct {
+	path string
+	next http.RoundTripper
+
+	mu          sync.Mutex
+	maxBodySize int64
+	cassette    Cassette
+	dirty       bool
+}
+
+// NewRecorder crée un enregistreur écrivant dans path ; next effectue les vraies requêtes
+// (transport du client avec WithRecorder, sinon http.DefaultTransport)
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	return &Recorder{
+		path:        path,
+		next:        next,
+		maxBodySize: DefaultMaxResponseSize,
+		cassette:    Cassette{Version: CassetteVersion},
+	}
+}
+
+// WithRecorder enregistre toutes les requêtes du client avec un enregistreur existant,
+// partagé par exemple avec le téléchargement des images.
+// Un enregistreur créé sans transport utilise celui du client ; les corps enregistrés
+// sont limités à la taille maximale des réponses du client.
+// L'appelant écrit la cassette avec Close une fois les requêtes terminées.
+func WithRecorder(recorder *Recorder) Option {
+	return func(c *Client) {
+		c.wrappers = append(c.wrappers, func(next http.RoundTripper) http.RoundTripper {
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+			if recorder.next == nil {
+				recorder.next = next
+			}
+			recorder.maxBodySize = c.maxResponseSize
+			return recorder
+		})
+	}
+}
+
+// RoundTrip effectue la requête et l'ajoute à la cassette
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	interaction := Interaction{
+		Method:     req.Method,
+		URL:        req.URL.RequestURI(),
+		RecordedAt: time.Now(),
+	}
+
+	r.mu.Lock()
+	next, limit := r.next, r.maxBodySize
+	r.mu.Unlock()
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		interaction.Error = err.Error()
+		r.record(interaction)
+		return nil, err
+	}
+
+	reader := io.Reader(resp.Body)
+	if limit > 0 {
+		reader = io.LimitReader(resp.Body, limit+1)
+	}
+	body, readErr := io.ReadAll(reader)
+
+	interaction.Status = resp.StatusCode
+	interaction.Header = resp.Header.Clone()
+
+	if limit > 0 && int64(len(body)) > limit {
+		// Corps non enregistré ; le client reçoit le flux complet et applique sa propre limite
+		interaction.Error = fmt.Sprintf("%v: plus de %d octets, corps non enregistré", ErrResponseTooLarge, limit)
+		r.record(interaction)
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	if utf8.Valid(body) {
+		interaction.Body = string(body)
+	} else {
+		interaction.BodyBase64 = body
+	}
+	if readErr != nil {
+		interaction.Error = readErr.Error()
+	}
+	r.record(interaction)
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, readErr
+}
+
+// record ajoute une interaction à la cassette en mémoire
+func (r *Recorder) record(interaction Interaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.dirty = true
+}
+
+// Len retourne le nombre d'interactions enregistrées
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.cassette.Interactions)
+}
+
+// Flush écrit sur disque les interactions enregistrées jusqu'ici
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erreur lors de l'écriture de la cassette: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(r.path, data); err != nil {
+		return fmt.Errorf("erreur lors de l'écriture de la cassette: %w", err)
+	}
+	r.dirty = false
+	return nil
+}
+
+// Close écrit la cassette ; l'enregistreur reste utilisable
+func (r *Recorder) Close() error {
+	return r.Flush()
+}
+
+// Replayer est un http.RoundTripper qui sert les réponses d'une cassette
+type Replayer struct {
+	path string
+
+	mu           sync.Mutex
+	interactions map[string][]Interaction
+	served       map[string]int
+}
+
+// LoadCassette lit une cassette pour la relecture
+func LoadCassette(path string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la lecture de la cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("erreur lors du parsing de la cassette: %w", err)
+	}
+	if cassette.Version != CassetteVersion {
+		return nil, fmt.Errorf("version de cassette %d non supportée (attendue %d)", cassette.Version, CassetteVersion)
+	}
+
+	r := &Replayer{
+		path:         path,
+		interactions: make(map[string][]Interaction),
+		served:       make(map[string]int),
+	}
+	for _, interaction := range cassette.Interactions {
+		key := interaction.Method + " " + interaction.URL
+		r.interactions[key] = append(r.interactions[key], interaction)
+	}
+	return r, nil
+}
+
+// WithReplay sert toutes les requêtes du client depuis une cassette chargée par LoadCassette
+func WithReplay(replayer *Replayer) Option {
+	return func(c *Client) {
+		c.wrappers = append(c.wrappers, func(http.RoundTripper) http.RoundTripper {
+			return replayer
+		})
+	}
+}
+
+// RoundTrip retourne la réponse enregistrée pour la requête.
+// Les réponses d'une même requête sont servies dans l'ordre, la dernière étant répétée.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := interactionKey(req.Method, req)
+
+	r.mu.Lock()
+	recorded := r.interactions[key]
+	if len(recorded) == 0 {
+		r.mu.Unlock()
+		log.Printf("❌ Relecture: %s absente de la cassette %s\n", key, r.path)
+		return nil, fmt.Errorf("%w: %s", ErrUnrecorded, key)
+	}
+	index := r.served[key]
+	if index >= len(recorded) {
+		index = len(recorded) - 1
+	}
+	r.served[key]++
+	r.mu.Unlock()
+
+	interaction := recorded[index]
+	if interaction.Error != "" && interaction.Status == 0 {
+		return nil, errors.New(interaction.Error)
+	}
+
+	header := interaction.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	body := []byte(interaction.Body)
+	if interaction.BodyBase64 != nil {
+		body = interaction.BodyBase64
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"groupie-tracker/fakeapi"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// countingTransport compte les requêtes envoyées au transport suivant
+type countingTransport struct {
+	next  http.RoundTripper
+	count atomic.Int64
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count.Add(1)
+	return t.next.RoundTrip(req)
+}
+
+// offlineTransport échoue à chaque requête, comme un réseau indisponible
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("réseau indisponible")
+}
+
+func TestCassetteUsesCallerHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(fakeapi.New(nil, fakeapi.Options{}))
+	defer srv.Close()
+	baseURL := srv.URL + fakeapi.Prefix
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	transport := &countingTransport{next: http.DefaultTransport}
+	recorder := NewRecorder(path, nil)
+	recording := NewClient(
+		WithBaseURL(baseURL),
+		WithRetry(NoRetry),
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithRecorder(recorder),
+	)
+	recorded, err := recording.GetArtistsContext(context.Background())
+	if err != nil {
+		t.Fatalf("enregistrement: %v", err)
+	}
+	if transport.count.Load() == 0 {
+		t.Fatalf("le transport de WithHTTPClient n'a pas été utilisé pendant l'enregistrement")
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"client par défaut", nil},
+		{"client de l'appelant hors ligne", []Option{WithHTTPClient(&http.Client{Transport: offlineTransport{}})}},
+		{"transport hors ligne", []Option{WithTransport(offlineTransport{})}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithBaseURL(baseURL), WithRetry(NoRetry)}, tt.opts...)
+			replaying := NewClient(append(opts, WithReplay(replayer))...)
+
+			artists, err := replaying.GetArtistsContext(context.Background())
+			if err != nil {
+				t.Fatalf("relecture: %v", err)
+			}
+			if len(artists) != len(recorded) {
+				t.Errorf("relecture: %d artistes, attendu %d", len(artists), len(recorded))
+			}
+
+			_, err = replaying.GetRelationContext(context.Background(), 1)
+			if !errors.Is(err, ErrUnrecorded) {
+				t.Errorf("requête absente de la cassette: %v, attendu %v", err, ErrUnrecorded)
+			}
+		})
+	}
+}
+
+func TestRecorderWritesOnClose(t *testing.T) {
+	srv := httptest.NewServer(fakeapi.New(nil, fakeapi.Options{}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder := NewRecorder(path, nil)
+	client := NewClient(WithBaseURL(srv.URL+fakeapi.Prefix), WithRetry(NoRetry), WithRecorder(recorder))
+	ctx := context.Background()
+	for id := 1; id <= 3; id++ {
+		if _, err := client.GetArtistContext(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("cassette écrite avant Close (%v)", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCassette(path); err != nil {
+		t.Fatalf("LoadCassette(): %v", err)
+	}
+	if got := recorder.Len(); got != 3 {
+		t.Errorf("Len() = %d, attendu 3", got)
+	}
+}
+
+func TestRecorderSkipsOversizedBody(t *testing.T) {
+	srv := httptest.NewServer(fakeapi.New(nil, fakeapi.Options{}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder := NewRecorder(path, nil)
+	client := NewClient(WithBaseURL(srv.URL+fakeapi.Prefix), WithRetry(NoRetry), WithMaxResponseSize(64), WithRecorder(recorder))
+	if _, err := client.GetArtistsContext(context.Background()); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("GetArtists() = %v, attendu %v", err, ErrResponseTooLarge)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		t.Fatal(err)
+	}
+	for _, interaction := range cassette.Interactions {
+		if len(interaction.Body) > 64 || len(interaction.BodyBase64) > 64 {
+			t.Errorf("%s: corps de %d octets enregistré malgré la limite", interaction.URL, len(interaction.Body)+len(interaction.BodyBase64))
+		}
+		if interaction.Error == "" {
+			t.Errorf("%s: réponse tronquée sans erreur enregistrée", interaction.URL)
+		}
+	}
+}
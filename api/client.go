@@ -1,51 +1,339 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"groupie-tracker/models"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
 	BaseURL = "https://groupietrackers.herokuapp.com/api"
+
+	// DefaultTimeout est la durée maximale d'une requête si aucune option ne la change
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultUserAgent est l'en-tête User-Agent envoyé par défaut
+	DefaultUserAgent = "groupie-tracker/1.0"
 )
 
 // Client gère les requêtes à l'API
 type Client struct {
-	baseURL string
-	client  *http.Client
+	baseURL   string
+	client    *http.Client
+	transport http.RoundTripper
+	wrappers  []func(http.RoundTripper) http.RoundTripper // appliqués au transport effectif (cassettes)
+	timeout   time.Duration
+	userAgent string
+	headers   http.Header
+	retry     RetryPolicy
+
+	breakerSettings BreakerSettings
+	breakersMu      sync.Mutex
+	breakers        map[string]*circuitBreaker
+
+	maxResponseSize int64
+	decodeMode      DecodeMode
+
+	cache       *diskCache
+	cacheMu     sync.Mutex
+	cacheStatus map[string]CacheStatus
+
+	metrics *Metrics
+}
+
+// Option configure un Client lors de sa création
+type Option func(*Client)
+
+// WithBaseURL change l'URL de base de l'API (miroir local, serveur de test...)
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.baseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithHTTPClient utilise un *http.Client fourni par l'appelant
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
+
+// WithTransport remplace le transport HTTP utilisé par le client
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithTimeout définit la durée maximale de chaque requête (0 = aucune limite)
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		if timeout >= 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// WithUserAgent définit l'en-tête User-Agent des requêtes
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// WithHeader ajoute un en-tête envoyé avec chaque requête
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
 }
 
 // NewClient crée un nouveau client API
-func NewClient() *Client {
-	return &Client{
-		baseURL: BaseURL,
-		client:  &http.Client{},
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:   BaseURL,
+		client:    &http.Client{},
+		timeout:   DefaultTimeout,
+		userAgent: DefaultUserAgent,
+		headers:   make(http.Header),
+		retry:     DefaultRetryPolicy,
+
+		breakerSettings: DefaultBreakerSettings,
+		breakers:        make(map[string]*circuitBreaker),
+		cacheStatus:     make(map[string]CacheStatus),
+		maxResponseSize: DefaultMaxResponseSize,
+		metrics:         NewMetrics(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// Copie du client HTTP pour ne pas modifier celui de l'appelant
+	httpClient := *c.client
+	if c.transport != nil {
+		httpClient.Transport = c.transport
+	}
+	// Les cassettes enveloppent le transport réellement utilisé, y compris celui de WithHTTPClient
+	for _, wrap := range c.wrappers {
+		httpClient.Transport = wrap(httpClient.Transport)
 	}
+	httpClient.Timeout = c.timeout
+	c.client = &httpClient
+
+	return c
 }
 
-// GetArtists récupère tous les artistes
-func (c *Client) GetArtists() ([]models.Artist, error) {
-	resp, err := c.client.Get(c.baseURL + "/artists")
+// BaseURL retourne l'URL de base utilisée par le client
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// get envoie une requête GET vers un endpoint de l'API, via le cache disque s'il est activé
+func (c *Client) get(ctx context.Context, endpoint string) (*http.Response, error) {
+	if c.cache != nil {
+		return c.getCached(ctx, endpoint)
+	}
+	return c.fetch(ctx, endpoint, nil)
+}
+
+// fetch envoie une requête GET vers un endpoint de l'API.
+// Les erreurs réseau et les codes 5xx/429 sont relancés selon la politique de relance,
+// et le disjoncteur de l'endpoint refuse les appels tant qu'il est ouvert.
+func (c *Client) fetch(ctx context.Context, endpoint string, header http.Header) (*http.Response, error) {
+	breaker := c.breaker(endpoint)
+	var lastErr error
+
+	for attempt := 1; ; attempt++ {
+		if err := breaker.allow(); err != nil {
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w (dernière erreur: %v)", err, lastErr)
+			}
+			return nil, err
+		}
+
+		start := time.Now()
+		resp, err := c.send(ctx, endpoint, header)
+		c.metrics.observeRequest(endpoint, time.Since(start))
+		if err == nil {
+			resp.Body = &countingBody{ReadCloser: resp.Body, metrics: c.metrics, endpoint: endpoint}
+		}
+
+		var wait time.Duration
+		switch {
+		case err != nil:
+			if errors.Is(err, ErrUnrecorded) {
+				// Relancer ne servira à rien : la cassette ne contient pas cette requête
+				breaker.abort()
+				return nil, err
+			}
+			if ctx.Err() != nil {
+				breaker.abort()
+				return nil, err
+			}
+			breaker.failure()
+			lastErr = err
+
+		case retryableStatus(resp.StatusCode):
+			breaker.failure()
+			if attempt >= c.retry.MaxAttempts {
+				// Dernière tentative : l'appelant traite le code HTTP
+				return resp, nil
+			}
+			lastErr = fmt.Errorf("erreur HTTP: %d", resp.StatusCode)
+			if after, ok := retryAfter(resp, time.Now()); ok {
+				wait = after
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+		default:
+			breaker.success()
+			return resp, nil
+		}
+
+		if attempt >= c.retry.MaxAttempts {
+			return nil, lastErr
+		}
+
+		delay := c.retry.backoff(attempt)
+		if wait > delay {
+			delay = wait
+		}
+		if c.retry.MaxDelay > 0 && delay > c.retry.MaxDelay {
+			// Le serveur demande d'attendre plus longtemps que ce qui est autorisé
+			return nil, fmt.Errorf("abandon après %d tentatives, réessayer dans %s: %w", attempt, delay.Round(time.Second), lastErr)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send envoie une seule requête GET, sans relance
+func (c *Client) send(ctx context.Context, endpoint string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération des artistes: %w", err)
+		return nil, err
+	}
+
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return c.client.Do(req)
+}
+
+// getJSON récupère un endpoint et décode sa réponse JSON dans out.
+// Toutes les erreurs retournées sont des *APIError.
+func (c *Client) getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	err := c.decodeEndpoint(ctx, endpoint, out)
+	c.metrics.observeError(endpoint, err)
+	return err
+}
+
+// decodeEndpoint effectue la requête et décode la réponse (voir getJSON)
+func (c *Client) decodeEndpoint(ctx context.Context, endpoint string, out interface{}) error {
+	resp, err := c.get(ctx, endpoint)
+	if err != nil {
+		return &APIError{Endpoint: endpoint, Kind: ErrNetwork, Err: err}
 	}
 	defer resp.Body.Close()
 
+	contentType := resp.Header.Get("Content-Type")
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("erreur HTTP: %d", resp.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodyExcerpt+1))
+		kind := ErrHTTP
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			kind = ErrNotFound
+		case resp.StatusCode == http.StatusBadRequest && isEntityEndpoint(endpoint):
+			// L'API répond 400 à un ID invalide sur /{endpoint}/{id}
+			kind = ErrNotFound
+		}
+		return &APIError{
+			Endpoint:    endpoint,
+			StatusCode:  resp.StatusCode,
+			ContentType: contentType,
+			Body:        excerpt(body),
+			Kind:        kind,
+		}
+	}
+
+	if c.maxResponseSize > 0 && resp.ContentLength > c.maxResponseSize {
+		return &APIError{
+			Endpoint:    endpoint,
+			StatusCode:  resp.StatusCode,
+			ContentType: contentType,
+			Kind:        ErrResponseTooLarge,
+			Err:         fmt.Errorf("%d octets, maximum %d", resp.ContentLength, c.maxResponseSize),
+		}
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body := newLimitedReader(resp.Body, c.maxResponseSize)
+	head := &headBuffer{}
+
+	offset, err := decodeJSON(io.TeeReader(body, head), out, c.decodeMode)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la lecture de la réponse: %w", err)
+		if errors.Is(err, ErrResponseTooLarge) {
+			return &APIError{
+				Endpoint:    endpoint,
+				StatusCode:  resp.StatusCode,
+				ContentType: contentType,
+				Body:        excerpt(head.data),
+				Kind:        ErrResponseTooLarge,
+				Err:         fmt.Errorf("plus de %d octets", c.maxResponseSize),
+			}
+		} else if errors.Is(err, io.ErrUnexpectedEOF) {
+			// Réponse tronquée : l'erreur se situe à la fin des données reçues
+			offset = body.read
+		} else if !isDecodeFailure(err) {
+			return &APIError{Endpoint: endpoint, StatusCode: resp.StatusCode, ContentType: contentType, Kind: ErrNetwork, Err: err}
+		}
+		return decodeError(endpoint, resp.StatusCode, contentType, head.data, offset, err)
 	}
 
+	// Lire la fin de la réponse pour réutiliser la connexion (et compléter le cache)
+	io.Copy(io.Discard, body)
+
+	return nil
+}
+
+// GetArtists récupère tous les artistes
+func (c *Client) GetArtists() ([]models.Artist, error) {
+	return c.GetArtistsContext(context.Background())
+}
+
+// GetArtistsContext récupère tous les artistes en respectant l'annulation du contexte
+func (c *Client) GetArtistsContext(ctx context.Context) ([]models.Artist, error) {
 	var artists []models.Artist
-	if err := json.Unmarshal(body, &artists); err != nil {
-		return nil, fmt.Errorf("erreur lors du parsing JSON: %w", err)
+	if err := c.getJSON(ctx, EndpointArtists, &artists); err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des artistes: %w", err)
 	}
 
 	return artists, nil
@@ -53,23 +341,17 @@ func (c *Client) GetArtists() ([]models.Artist, error) {
 
 // GetLocations récupère tous les lieux
 func (c *Client) GetLocations() ([]models.Location, error) {
-	resp, err := c.client.Get(c.baseURL + "/locations")
-	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération des lieux: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetLocationsContext(context.Background())
+}
 
+// GetLocationsContext récupère tous les lieux en respectant l'annulation du contexte
+func (c *Client) GetLocationsContext(ctx context.Context) ([]models.Location, error) {
 	var locationData struct {
 		Index []models.Location `json:"index"`
 	}
 
-	if err := json.Unmarshal(body, &locationData); err != nil {
-		return nil, err
+	if err := c.getJSON(ctx, EndpointLocations, &locationData); err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des lieux: %w", err)
 	}
 
 	return locationData.Index, nil
@@ -77,23 +359,17 @@ func (c *Client) GetLocations() ([]models.Location, error) {
 
 // GetDates récupère toutes les dates
 func (c *Client) GetDates() ([]models.Date, error) {
-	resp, err := c.client.Get(c.baseURL + "/dates")
-	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération des dates: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetDatesContext(context.Background())
+}
 
+// GetDatesContext récupère toutes les dates en respectant l'annulation du contexte
+func (c *Client) GetDatesContext(ctx context.Context) ([]models.Date, error) {
 	var dateData struct {
 		Index []models.Date `json:"index"`
 	}
 
-	if err := json.Unmarshal(body, &dateData); err != nil {
-		return nil, err
+	if err := c.getJSON(ctx, EndpointDates, &dateData); err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des dates: %w", err)
 	}
 
 	return dateData.Index, nil
@@ -101,23 +377,17 @@ func (c *Client) GetDates() ([]models.Date, error) {
 
 // GetRelations récupère toutes les relations
 func (c *Client) GetRelations() ([]models.Relation, error) {
-	resp, err := c.client.Get(c.baseURL + "/relation")
-	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération des relations: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+	return c.GetRelationsContext(context.Background())
+}
 
+// GetRelationsContext récupère toutes les relations en respectant l'annulation du contexte
+func (c *Client) GetRelationsContext(ctx context.Context) ([]models.Relation, error) {
 	var relationData struct {
 		Index []models.Relation `json:"index"`
 	}
 
-	if err := json.Unmarshal(body, &relationData); err != nil {
-		return nil, err
+	if err := c.getJSON(ctx, EndpointRelations, &relationData); err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des relations: %w", err)
 	}
 
 	return relationData.Index, nil
@@ -125,35 +395,92 @@ func (c *Client) GetRelations() ([]models.Relation, error) {
 
 // LoadAllData charge toutes les données de l'API
 func (c *Client) LoadAllData() (*models.APIData, error) {
+	return c.LoadAllDataContext(context.Background())
+}
+
+// LoadAllDataContext charge toutes les données de l'API.
+// Le chargement s'arrête dès que le contexte est annulé ou que son échéance est dépassée.
+func (c *Client) LoadAllDataContext(ctx context.Context) (*models.APIData, error) {
+	data, _, err := c.LoadAllDataWithReport(ctx)
+	return data, err
+}
+
+// LoadAllDataWithReport charge les quatre endpoints en parallèle et retourne,
+// en plus des données, un rapport indiquant l'état de chaque endpoint.
+// Les artistes et les relations sont obligatoires : si l'un d'eux échoue,
+// aucune donnée n'est retournée mais le rapport reste disponible.
+// Les lieux et les dates sont remplacés par des listes vides en cas d'échec.
+func (c *Client) LoadAllDataWithReport(ctx context.Context) (*models.APIData, *LoadReport, error) {
 	data := &models.APIData{}
+	start := time.Now()
 
-	artists, err := c.GetArtists()
-	if err != nil {
-		return nil, fmt.Errorf("erreur chargement artistes: %w", err)
+	fetchers := []struct {
+		endpoint string
+		required bool
+		fetch    func() (int, error)
+	}{
+		{EndpointArtists, true, func() (int, error) {
+			artists, err := c.GetArtistsContext(ctx)
+			data.Artists = artists
+			return len(artists), err
+		}},
+		{EndpointRelations, true, func() (int, error) {
+			relations, err := c.GetRelationsContext(ctx)
+			data.Relations = relations
+			return len(relations), err
+		}},
+		{EndpointLocations, false, func() (int, error) {
+			locations, err := c.GetLocationsContext(ctx)
+			data.Locations = locations
+			return len(locations), err
+		}},
+		{EndpointDates, false, func() (int, error) {
+			dates, err := c.GetDatesContext(ctx)
+			data.Dates = dates
+			return len(dates), err
+		}},
 	}
-	data.Artists = artists
 
-	relations, err := c.GetRelations()
-	if err != nil {
-		return nil, fmt.Errorf("erreur chargement relations: %w", err)
+	report := &LoadReport{Endpoints: make([]EndpointReport, len(fetchers))}
+
+	var wg sync.WaitGroup
+	for i, f := range fetchers {
+		wg.Add(1)
+		go func(i int, endpoint string, required bool, fetch func() (int, error)) {
+			defer wg.Done()
+			begin := time.Now()
+			count, err := fetch()
+			report.Endpoints[i] = EndpointReport{
+				Endpoint: endpoint,
+				Required: required,
+				Err:      err,
+				Duration: time.Since(begin),
+				Count:    count,
+				Breaker:  c.BreakerState(endpoint),
+				Cache:    c.CacheStatus(endpoint),
+			}
+		}(i, f.endpoint, f.required, f.fetch)
 	}
-	data.Relations = relations
+	wg.Wait()
+	report.Duration = time.Since(start)
 
-	locations, err := c.GetLocations()
-	if err != nil {
-		// Non bloquant
-		data.Locations = []models.Location{}
-	} else {
-		data.Locations = locations
+	if err := ctx.Err(); err != nil {
+		return nil, report, fmt.Errorf("chargement interrompu: %w", err)
 	}
 
-	dates, err := c.GetDates()
-	if err != nil {
-		// Non bloquant
+	for _, e := range report.Endpoints {
+		if e.Required && !e.OK() {
+			return nil, report, fmt.Errorf("erreur chargement %s: %w", e.Label(), e.Err)
+		}
+	}
+
+	// Non bloquant
+	if data.Locations == nil {
+		data.Locations = []models.Location{}
+	}
+	if data.Dates == nil {
 		data.Dates = []models.Date{}
-	} else {
-		data.Dates = dates
 	}
 
-	return data, nil
+	return data, report, nil
 }
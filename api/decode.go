@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxResponseSize est la taille maximale d'une réponse (32 Mio)
+const DefaultMaxResponseSize = 32 << 20
+
+// ErrResponseTooLarge est retournée quand une réponse dépasse la taille maximale autorisée
+var ErrResponseTooLarge = errors.New("réponse trop volumineuse")
+
+// ErrTrailingData est retournée en mode strict quand des données suivent la valeur JSON
+var ErrTrailingData = errors.New("données inattendues après la valeur JSON")
+
+// DecodeMode règle la sévérité du décodage JSON
+type DecodeMode struct {
+	DisallowUnknownFields bool // Refuser les champs absents des modèles
+	RejectTrailingData    bool // Refuser tout contenu après la valeur JSON
+}
+
+// StrictDecoding active toutes les vérifications.
+// L'API officielle renvoie des champs non modélisés (locations, concertDates...) :
+// ce mode est surtout utile face à un miroir ou un faux serveur.
+var StrictDecoding = DecodeMode{DisallowUnknownFields: true, RejectTrailingData: true}
+
+// WithMaxResponseSize limite la taille des réponses acceptées (0 = aucune limite)
+func WithMaxResponseSize(size int64) Option {
+	return func(c *Client) {
+		if size >= 0 {
+			c.maxResponseSize = size
+		}
+	}
+}
+
+// WithDecodeMode définit la sévérité du décodage JSON
+func WithDecodeMode(mode DecodeMode) Option {
+	return func(c *Client) {
+		c.decodeMode = mode
+	}
+}
+
+// limitedReader retourne ErrResponseTooLarge dès que la limite est dépassée
+// (contrairement à io.LimitReader qui tronque silencieusement)
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	unlimited bool
+	read      int64 // Nombre total d'octets lus
+}
+
+func newLimitedReader(r io.Reader, limit int64) *limitedReader {
+	return &limitedReader{r: r, remaining: limit, unlimited: limit <= 0}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.unlimited {
+		n, err := l.r.Read(p)
+		l.read += int64(n)
+		return n, err
+	}
+	if l.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	// Lire un octet de plus que la limite pour détecter le dépassement
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrResponseTooLarge
+	}
+	return n, err
+}
+
+// headBuffer conserve le début du flux lu pour illustrer les erreurs
+type headBuffer struct {
+	data []byte
+}
+
+func (h *headBuffer) Write(p []byte) (int, error) {
+	if room := maxBodyExcerpt + 1 - len(h.data); room > 0 {
+		if len(p) < room {
+			room = len(p)
+		}
+		h.data = append(h.data, p[:room]...)
+	}
+	return len(p), nil
+}
+
+// decodeJSON décode un flux JSON dans out sans le charger entièrement en mémoire.
+// En cas d'erreur, la position (en octets) du problème est retournée avec l'erreur ;
+// les erreurs du décodeur (contenu invalide) sont marquées pour isDecodeFailure.
+func decodeJSON(r io.Reader, out interface{}, mode DecodeMode) (int64, error) {
+	stream := &streamReader{r: r}
+	decoder := json.NewDecoder(stream)
+	if mode.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(out); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return decodeOffset(err, decoder), stream.classify(err)
+	}
+
+	if mode.RejectTrailingData {
+		offset := decoder.InputOffset()
+		if _, err := decoder.Token(); err != io.EOF {
+			if err != nil && stream.failed(err) {
+				return offset, err
+			}
+			return offset, &jsonError{err: ErrTrailingData}
+		}
+	}
+
+	return 0, nil
+}
+
+// streamReader mémorise la dernière erreur de lecture du flux,
+// pour distinguer une panne du flux d'une erreur du décodeur
+type streamReader struct {
+	r   io.Reader
+	err error
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if err != nil && err != io.EOF {
+		s.err = err
+	}
+	return n, err
+}
+
+// failed indique si err vient de la lecture du flux (réseau, taille maximale)
+func (s *streamReader) failed(err error) bool {
+	return s.err != nil && errors.Is(err, s.err)
+}
+
+// classify marque err comme erreur de décodage si elle ne vient pas du flux
+func (s *streamReader) classify(err error) error {
+	if s.failed(err) {
+		return err
+	}
+	return &jsonError{err: err}
+}
+
+// jsonError est une erreur du décodeur JSON : contenu invalide, tronqué ou inattendu
+type jsonError struct {
+	err error
+}
+
+func (e *jsonError) Error() string { return e.err.Error() }
+func (e *jsonError) Unwrap() error { return e.err }
+
+// decodeOffset retrouve la position d'une erreur de décodage
+func decodeOffset(err error, decoder *json.Decoder) int64 {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Offset
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return typeErr.Offset
+	}
+
+	return decoder.InputOffset()
+}
+
+// isDecodeFailure distingue une erreur de contenu JSON d'une erreur de lecture du flux
+func isDecodeFailure(err error) bool {
+	var decodeErr *jsonError
+	return errors.As(err, &decodeErr)
+}
+
+// decodeError construit l'erreur de décodage avec sa position
+func decodeError(endpoint string, statusCode int, contentType string, head []byte, offset int64, err error) *APIError {
+	return &APIError{
+		Endpoint:    endpoint,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Body:        excerpt(head),
+		Kind:        ErrDecode,
+		Offset:      offset,
+		Err:         fmt.Errorf("position %d: %w", offset, err),
+	}
+}
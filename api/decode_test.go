@@ -0,0 +1,74 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// failingReader retourne son contenu puis une erreur de lecture
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, f.err
+	}
+	return n, err
+}
+
+func TestDecodeJSON(t *testing.T) {
+	errReset := errors.New("connexion interrompue")
+
+	tests := []struct {
+		name        string
+		reader      io.Reader
+		mode        DecodeMode
+		wantErr     error // nil : décodage réussi
+		wantFailure bool  // erreur de contenu JSON (et non de lecture)
+		wantOffset  int64
+	}{
+		{"valide", strings.NewReader(`{"id": 1}`), DecodeMode{}, nil, false, 0},
+		{"données en trop tolérées", strings.NewReader(`{"id": 1} {}`), DecodeMode{}, nil, false, 0},
+		{"syntaxe", strings.NewReader(`{"id": 1,, }`), DecodeMode{}, nil, true, 10},
+		{"tronqué", strings.NewReader(`{"id": 1`), DecodeMode{}, io.ErrUnexpectedEOF, true, 0},
+		{"vide", strings.NewReader(``), DecodeMode{}, io.ErrUnexpectedEOF, true, 0},
+		{"données en trop refusées", strings.NewReader(`{"id": 1} {}`), StrictDecoding, ErrTrailingData, true, 9},
+		{"champ inconnu refusé", strings.NewReader(`{"id": 1, "extra": 2}`), StrictDecoding, nil, true, 0},
+		{"type inattendu", strings.NewReader(`{"id": "un"}`), DecodeMode{}, nil, true, 11},
+		{"lecture interrompue", &failingReader{strings.NewReader(`{"id": `), errReset}, DecodeMode{}, errReset, false, 0},
+		{"réponse trop volumineuse", newLimitedReader(strings.NewReader(`{"id": 12345}`), 4), DecodeMode{}, ErrResponseTooLarge, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out struct {
+				ID int `json:"id"`
+			}
+			offset, err := decodeJSON(tt.reader, &out, tt.mode)
+
+			if err == nil {
+				if tt.wantFailure || tt.wantErr != nil {
+					t.Fatalf("decodeJSON() sans erreur, attendu une erreur")
+				}
+				return
+			}
+			if !tt.wantFailure && tt.wantErr == nil {
+				t.Fatalf("decodeJSON() = %v, attendu sans erreur", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("decodeJSON() = %v, attendu %v", err, tt.wantErr)
+			}
+			if got := isDecodeFailure(err); got != tt.wantFailure {
+				t.Errorf("isDecodeFailure(%v) = %v, attendu %v", err, got, tt.wantFailure)
+			}
+			if tt.wantOffset != 0 && offset != tt.wantOffset {
+				t.Errorf("position = %d, attendu %d", offset, tt.wantOffset)
+			}
+		})
+	}
+}
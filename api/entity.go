@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"groupie-tracker/models"
+)
+
+// getOne récupère une seule entité sur endpoint/{id} et la décode dans out
+func (c *Client) getOne(ctx context.Context, endpoint string, id int, out interface{}) error {
+	if id <= 0 {
+		return notFound(endpoint, id)
+	}
+	return c.getJSON(ctx, fmt.Sprintf("%s/%d", endpoint, id), out)
+}
+
+// isEntityEndpoint indique si le chemin désigne une seule entité ("/artists/3")
+func isEntityEndpoint(endpoint string) bool {
+	return metricsEndpoint(endpoint) != endpoint
+}
+
+// notFound construit l'erreur retournée pour un ID inconnu
+func notFound(endpoint string, id int) error {
+	return &APIError{Endpoint: fmt.Sprintf("%s/%d", endpoint, id), Kind: ErrNotFound}
+}
+
+// GetArtist récupère un artiste par son ID
+func (c *Client) GetArtist(id int) (*models.Artist, error) {
+	return c.GetArtistContext(context.Background(), id)
+}
+
+// GetArtistContext récupère un artiste par son ID en respectant l'annulation du contexte
+func (c *Client) GetArtistContext(ctx context.Context, id int) (*models.Artist, error) {
+	var artist models.Artist
+	if err := c.getOne(ctx, EndpointArtists, id, &artist); err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération de l'artiste %d: %w", id, err)
+	}
+
+	// L'API répond par un objet vide pour un ID inconnu
+	if artist.ID != id {
+		return nil, notFound(EndpointArtists, id)
+	}
+	return &artist, nil
+}
+
+// GetLocation récupère les lieux de concert d'un artiste par son ID
+func (c *Client) GetLocation(id int) (*models.Location, error) {
+	return c.GetLocationContext(context.Background(), id)
+}
+
+// GetLocationContext récupère les lieux de concert d'un artiste en respectant l'annulation du contexte
+func (c *Client) GetLocationContext(ctx context.Context, id int) (*models.Location, error) {
+	var location models.Location
+	if err := c.getOne(ctx, EndpointLocations, id, &location); err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des lieux %d: %w", id, err)
+	}
+
+	if location.ID != id {
+		return nil, notFound(EndpointLocations, id)
+	}
+	return &location, nil
+}
+
+// GetDate récupère les dates de concert d'un artiste par son ID
+func (c *Client) GetDate(id int) (*models.Date, error) {
+	return c.GetDateContext(context.Background(), id)
+}
+
+// GetDateContext récupère les dates de concert d'un artiste en respectant l'annulation du contexte
+func (c *Client) GetDateContext(ctx context.Context, id int) (*models.Date, error) {
+	var date models.Date
+	if err := c.getOne(ctx, EndpointDates, id, &date); err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des dates %d: %w", id, err)
+	}
+
+	if date.ID != id {
+		return nil, notFound(EndpointDates, id)
+	}
+	return &date, nil
+}
+
+// GetRelation récupère la relation lieux/dates d'un artiste par son ID
+func (c *Client) GetRelation(id int) (*models.Relation, error) {
+	return c.GetRelationContext(context.Background(), id)
+}
+
+// GetRelationContext récupère la relation d'un artiste en respectant l'annulation du contexte
+func (c *Client) GetRelationContext(ctx context.Context, id int) (*models.Relation, error) {
+	var relation models.Relation
+	if err := c.getOne(ctx, EndpointRelations, id, &relation); err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération de la relation %d: %w", id, err)
+	}
+
+	if relation.ID != id {
+		return nil, notFound(EndpointRelations, id)
+	}
+	return &relation, nil
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"groupie-tracker/fakeapi"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, opts fakeapi.Options, extra ...Option) (*Client, *fakeapi.Server) {
+	t.Helper()
+	server := fakeapi.New(nil, opts)
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+
+	options := append([]Option{WithBaseURL(srv.URL + fakeapi.Prefix), WithRetry(NoRetry)}, extra...)
+	return NewClient(options...), server
+}
+
+func TestEntityNotFound(t *testing.T) {
+	client, _ := newTestClient(t, fakeapi.Options{})
+
+	tests := []struct {
+		name     string
+		endpoint string
+		want     error
+	}{
+		{"ID inconnu", "/artists/9999", ErrNotFound},
+		{"ID invalide (400)", "/artists/abc", ErrNotFound},
+		{"relation invalide (400)", "/relation/x", ErrNotFound},
+		{"endpoint inconnu", "/inconnu", ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out map[string]interface{}
+			err := client.getJSON(context.Background(), tt.endpoint, &out)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("getJSON(%s) = %v, attendu %v", tt.endpoint, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsEntityEndpoint(t *testing.T) {
+	tests := map[string]bool{
+		"/artists":     false,
+		"/artists/3":   true,
+		"/relation/ab": true,
+		"/":            false,
+	}
+	for endpoint, want := range tests {
+		if got := isEntityEndpoint(endpoint); got != want {
+			t.Errorf("isEntityEndpoint(%q) = %v, attendu %v", endpoint, got, want)
+		}
+	}
+}
+
+func TestGetArtistByID(t *testing.T) {
+	client, server := newTestClient(t, fakeapi.Options{})
+	want := server.Data().Artists[0]
+
+	artist, err := client.GetArtist(want.ID)
+	if err != nil {
+		t.Fatalf("GetArtist(%d): %v", want.ID, err)
+	}
+	if artist.Name != want.Name {
+		t.Errorf("GetArtist(%d).Name = %q, attendu %q", want.ID, artist.Name, want.Name)
+	}
+
+	if _, err := client.GetArtist(0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetArtist(0) = %v, attendu ErrNotFound", err)
+	}
+}
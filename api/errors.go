@@ -0,0 +1,94 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// Catégories d'erreurs, utilisables avec errors.Is
+var (
+	ErrNetwork  = errors.New("erreur réseau")
+	ErrHTTP     = errors.New("erreur HTTP")
+	ErrDecode   = errors.New("réponse illisible")
+	ErrNotFound = errors.New("entité introuvable")
+)
+
+// maxBodyExcerpt est la taille maximale de l'extrait de réponse conservé dans une erreur
+const maxBodyExcerpt = 256
+
+// APIError décrit l'échec d'une requête vers un endpoint de l'API
+type APIError struct {
+	Endpoint    string // Chemin demandé, par exemple "/artists" ou "/relation/3"
+	StatusCode  int    // Code HTTP (0 si aucune réponse)
+	ContentType string // Type de contenu de la réponse
+	Body        string // Début de la réponse, tronqué
+	Offset      int64  // Position (en octets) d'une erreur de décodage
+	Kind        error  // Catégorie : ErrNetwork, ErrHTTP, ErrDecode, ErrNotFound ou ErrResponseTooLarge
+	Err         error  // Cause d'origine
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %v", e.Endpoint, e.Kind)
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&sb, " (%d %s)", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&sb, ": %v", e.Err)
+	}
+	if e.Body != "" {
+		fmt.Fprintf(&sb, " [%s: %q]", e.ContentType, e.Body)
+	}
+	return sb.String()
+}
+
+// Unwrap expose la catégorie et la cause pour errors.Is et errors.As
+func (e *APIError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// excerpt retourne le début d'un corps de réponse, tronqué sur une limite de caractère
+func excerpt(body []byte) string {
+	if len(body) <= maxBodyExcerpt {
+		return strings.TrimSpace(string(body))
+	}
+
+	cut := maxBodyExcerpt
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return strings.TrimSpace(string(body[:cut])) + "…"
+}
+
+// Describe retourne un message lisible pour l'utilisateur à partir d'une erreur du client
+func Describe(err error) string {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	switch {
+	case errors.Is(err, ErrUnrecorded):
+		return fmt.Sprintf("La requête %s n'a pas été enregistrée dans la cassette rejouée.", apiErr.Endpoint)
+	case errors.Is(err, ErrCircuitOpen):
+		return fmt.Sprintf("L'API ne répond plus sur %s, un nouvel essai sera fait dans quelques instants.", apiErr.Endpoint)
+	case errors.Is(err, ErrNotFound):
+		return fmt.Sprintf("Aucune donnée trouvée sur %s.", apiErr.Endpoint)
+	case errors.Is(err, ErrNetwork):
+		return fmt.Sprintf("Impossible de joindre l'API (%s). Veuillez vérifier votre connexion.", apiErr.Endpoint)
+	case errors.Is(err, ErrHTTP):
+		return fmt.Sprintf("L'API a répondu %d %s sur %s.", apiErr.StatusCode, http.StatusText(apiErr.StatusCode), apiErr.Endpoint)
+	case errors.Is(err, ErrResponseTooLarge):
+		return fmt.Sprintf("Réponse trop volumineuse sur %s (voir -max-response-size).", apiErr.Endpoint)
+	case errors.Is(err, ErrDecode):
+		return fmt.Sprintf("Réponse illisible de l'API sur %s (%s).", apiErr.Endpoint, apiErr.ContentType)
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"groupie-tracker/fakeapi"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// staticServer sert toujours le même corps JSON
+func staticServer(t *testing.T, body string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestAPIErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     func(t *testing.T) *Client
+		wantKind   error
+		wantCat    string
+		wantOffset int64 // 0 : non vérifié
+		wantDesc   string
+	}{
+		{
+			name: "endpoint absent",
+			client: func(t *testing.T) *Client {
+				c, _ := newTestClient(t, fakeapi.Options{Missing: []string{EndpointArtists}})
+				return c
+			},
+			wantKind: ErrNotFound, wantCat: ErrorCategoryNotFound, wantDesc: "Aucune donnée",
+		},
+		{
+			name: "erreur serveur",
+			client: func(t *testing.T) *Client {
+				c, _ := newTestClient(t, fakeapi.Options{ErrorRate: 1}, WithCircuitBreaker(BreakerSettings{}))
+				return c
+			},
+			wantKind: ErrHTTP, wantCat: ErrorCategoryHTTP, wantDesc: "503",
+		},
+		{
+			name: "JSON tronqué",
+			client: func(t *testing.T) *Client {
+				c, _ := newTestClient(t, fakeapi.Options{MalformedRate: 1})
+				return c
+			},
+			wantKind: ErrDecode, wantCat: ErrorCategoryDecode, wantDesc: "illisible",
+		},
+		{
+			name: "réponse trop volumineuse",
+			client: func(t *testing.T) *Client {
+				c, _ := newTestClient(t, fakeapi.Options{}, WithMaxResponseSize(32))
+				return c
+			},
+			wantKind: ErrResponseTooLarge, wantCat: ErrorCategoryTooLarge, wantDesc: "trop volumineuse",
+		},
+		{
+			name: "champ inconnu en mode strict",
+			client: func(t *testing.T) *Client {
+				return NewClient(WithBaseURL(staticServer(t, `[{"id": 1, "extra": true}]`)), WithRetry(NoRetry), WithDecodeMode(StrictDecoding))
+			},
+			wantKind: ErrDecode, wantCat: ErrorCategoryDecode, wantDesc: "illisible",
+		},
+		{
+			name: "données en trop en mode strict",
+			client: func(t *testing.T) *Client {
+				return NewClient(WithBaseURL(staticServer(t, `[{"id": 1}] [{"id": 2}]`)), WithRetry(NoRetry), WithDecodeMode(StrictDecoding))
+			},
+			wantKind: ErrDecode, wantCat: ErrorCategoryDecode, wantOffset: 11, wantDesc: "illisible",
+		},
+		{
+			name: "réseau indisponible",
+			client: func(t *testing.T) *Client {
+				return NewClient(WithTransport(offlineTransport{}), WithRetry(NoRetry))
+			},
+			wantKind: ErrNetwork, wantCat: ErrorCategoryNetwork, wantDesc: "Impossible de joindre",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.client(t).GetArtistsContext(context.Background())
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetArtists() = %v, attendu une *APIError", err)
+			}
+			if apiErr.Kind != tt.wantKind {
+				t.Errorf("Kind = %v, attendu %v (%v)", apiErr.Kind, tt.wantKind, err)
+			}
+			if got := errorCategory(err); got != tt.wantCat {
+				t.Errorf("errorCategory() = %q, attendu %q", got, tt.wantCat)
+			}
+			if tt.wantOffset != 0 && apiErr.Offset != tt.wantOffset {
+				t.Errorf("Offset = %d, attendu %d", apiErr.Offset, tt.wantOffset)
+			}
+			if got := Describe(err); !strings.Contains(got, tt.wantDesc) {
+				t.Errorf("Describe() = %q, attendu %q", got, tt.wantDesc)
+			}
+		})
+	}
+}
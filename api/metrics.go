@@ -0,0 +1,309 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets sont les bornes (en secondes) de l'histogramme des latences
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Catégories d'erreurs comptées par endpoint
+const (
+	ErrorCategoryNetwork     = "network"
+	ErrorCategoryHTTP        = "http"
+	ErrorCategoryNotFound    = "not_found"
+	ErrorCategoryDecode      = "decode"
+	ErrorCategoryTooLarge    = "too_large"
+	ErrorCategoryCircuitOpen = "circuit_open"
+	ErrorCategoryCanceled    = "canceled"
+)
+
+// Metrics collecte les statistiques des requêtes d'un ou plusieurs clients
+type Metrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointMetrics
+}
+
+// endpointMetrics contient les compteurs d'un endpoint
+type endpointMetrics struct {
+	requests     int64
+	errors       map[string]int64
+	bytes        int64
+	bucketCounts []int64
+	latencyCount int64
+	latencySum   float64
+}
+
+// NewMetrics crée un collecteur vide avec les bornes de latence par défaut
+func NewMetrics() *Metrics {
+	return &Metrics{
+		buckets:   DefaultLatencyBuckets,
+		endpoints: make(map[string]*endpointMetrics),
+	}
+}
+
+// WithMetrics partage un collecteur entre plusieurs clients
+func WithMetrics(m *Metrics) Option {
+	return func(c *Client) {
+		if m != nil {
+			c.metrics = m
+		}
+	}
+}
+
+// Metrics retourne le collecteur de statistiques du client
+func (c *Client) Metrics() *Metrics {
+	return c.metrics
+}
+
+// metricsEndpoint regroupe "/artists/3" sous "/artists" pour limiter le nombre de séries
+func metricsEndpoint(endpoint string) string {
+	if i := strings.Index(endpoint[min(1, len(endpoint)):], "/"); i >= 0 {
+		return endpoint[:i+1]
+	}
+	return endpoint
+}
+
+// endpoint retourne (en le créant) les compteurs d'un endpoint ; mu doit être verrouillé
+func (m *Metrics) endpoint(endpoint string) *endpointMetrics {
+	endpoint = metricsEndpoint(endpoint)
+	e, ok := m.endpoints[endpoint]
+	if !ok {
+		e = &endpointMetrics{
+			errors:       make(map[string]int64),
+			bucketCounts: make([]int64, len(m.buckets)),
+		}
+		m.endpoints[endpoint] = e
+	}
+	return e
+}
+
+// observeRequest compte une requête HTTP envoyée et sa latence (jusqu'aux en-têtes)
+func (m *Metrics) observeRequest(endpoint string, latency time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := m.endpoint(endpoint)
+	e.requests++
+	seconds := latency.Seconds()
+	e.latencyCount++
+	e.latencySum += seconds
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			e.bucketCounts[i]++
+			break
+		}
+	}
+}
+
+// observeBytes ajoute les octets reçus pour un endpoint
+func (m *Metrics) observeBytes(endpoint string, n int64) {
+	if m == nil || n == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoint(endpoint).bytes += n
+}
+
+// observeError compte une erreur dans sa catégorie
+func (m *Metrics) observeError(endpoint string, err error) {
+	if m == nil || err == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoint(endpoint).errors[errorCategory(err)]++
+}
+
+// errorCategory classe une erreur du client
+func errorCategory(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorCategoryCanceled
+	case errors.Is(err, ErrCircuitOpen):
+		return ErrorCategoryCircuitOpen
+	case errors.Is(err, ErrResponseTooLarge):
+		return ErrorCategoryTooLarge
+	case errors.Is(err, ErrNotFound):
+		return ErrorCategoryNotFound
+	case errors.Is(err, ErrDecode):
+		return ErrorCategoryDecode
+	case errors.Is(err, ErrHTTP):
+		return ErrorCategoryHTTP
+	default:
+		return ErrorCategoryNetwork
+	}
+}
+
+// countingBody compte les octets lus dans le corps d'une réponse
+type countingBody struct {
+	io.ReadCloser
+	metrics  *Metrics
+	endpoint string
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.metrics.observeBytes(b.endpoint, int64(n))
+	return n, err
+}
+
+// EndpointMetrics est une copie des statistiques d'un endpoint
+type EndpointMetrics struct {
+	Endpoint     string
+	Requests     int64
+	Errors       map[string]int64
+	Bytes        int64
+	LatencyCount int64
+	LatencySum   time.Duration
+
+	// LatencyBuckets compte les requêtes par borne de LatencyBounds (non cumulé) ;
+	// les requêtes au-delà de la dernière borne ne sont comptées que dans LatencyCount
+	LatencyBounds  []float64
+	LatencyBuckets []int64
+}
+
+// ErrorCount retourne le nombre total d'erreurs
+func (e EndpointMetrics) ErrorCount() int64 {
+	var total int64
+	for _, n := range e.Errors {
+		total += n
+	}
+	return total
+}
+
+// AverageLatency retourne la latence moyenne des requêtes
+func (e EndpointMetrics) AverageLatency() time.Duration {
+	if e.LatencyCount == 0 {
+		return 0
+	}
+	return e.LatencySum / time.Duration(e.LatencyCount)
+}
+
+// Snapshot retourne les statistiques de chaque endpoint, triées par nom
+func (m *Metrics) Snapshot() []EndpointMetrics {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make([]EndpointMetrics, 0, len(m.endpoints))
+	for _, name := range m.names() {
+		e := m.endpoints[name]
+		errs := make(map[string]int64, len(e.errors))
+		for category, n := range e.errors {
+			errs[category] = n
+		}
+		snapshot = append(snapshot, EndpointMetrics{
+			Endpoint:     name,
+			Requests:     e.requests,
+			Errors:       errs,
+			Bytes:        e.bytes,
+			LatencyCount: e.latencyCount,
+			LatencySum:   time.Duration(e.latencySum * float64(time.Second)),
+
+			LatencyBounds:  m.buckets,
+			LatencyBuckets: append([]int64(nil), e.bucketCounts...),
+		})
+	}
+	return snapshot
+}
+
+// names retourne les endpoints triés ; mu doit être verrouillé
+func (m *Metrics) names() []string {
+	names := make([]string, 0, len(m.endpoints))
+	for name := range m.endpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// labelEscaper échappe une valeur d'étiquette selon le format texte de Prometheus
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// label formate une étiquette Prometheus (endpoint="/artists")
+func label(name, value string) string {
+	return name + `="` + labelEscaper.Replace(value) + `"`
+}
+
+// WritePrometheus écrit les statistiques au format texte de Prometheus.
+// Les compteurs sont copiés avant l'écriture : un client lent ne bloque pas les requêtes.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	snapshot := m.Snapshot()
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "# HELP groupie_api_requests_total Requêtes HTTP envoyées à l'API, relances comprises.")
+	fmt.Fprintln(bw, "# TYPE groupie_api_requests_total counter")
+	for _, e := range snapshot {
+		fmt.Fprintf(bw, "groupie_api_requests_total{%s} %d\n", label("endpoint", e.Endpoint), e.Requests)
+	}
+
+	fmt.Fprintln(bw, "# HELP groupie_api_errors_total Erreurs retournées par le client, par catégorie.")
+	fmt.Fprintln(bw, "# TYPE groupie_api_errors_total counter")
+	for _, e := range snapshot {
+		categories := make([]string, 0, len(e.Errors))
+		for category := range e.Errors {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		for _, category := range categories {
+			fmt.Fprintf(bw, "groupie_api_errors_total{%s,%s} %d\n",
+				label("endpoint", e.Endpoint), label("category", category), e.Errors[category])
+		}
+	}
+
+	fmt.Fprintln(bw, "# HELP groupie_api_response_bytes_total Octets reçus de l'API.")
+	fmt.Fprintln(bw, "# TYPE groupie_api_response_bytes_total counter")
+	for _, e := range snapshot {
+		fmt.Fprintf(bw, "groupie_api_response_bytes_total{%s} %d\n", label("endpoint", e.Endpoint), e.Bytes)
+	}
+
+	fmt.Fprintln(bw, "# HELP groupie_api_request_duration_seconds Latence des requêtes jusqu'à la réception des en-têtes.")
+	fmt.Fprintln(bw, "# TYPE groupie_api_request_duration_seconds histogram")
+	for _, e := range snapshot {
+		endpoint := label("endpoint", e.Endpoint)
+		var cumulative int64
+		for i, bound := range e.LatencyBounds {
+			cumulative += e.LatencyBuckets[i]
+			fmt.Fprintf(bw, "groupie_api_request_duration_seconds_bucket{%s,%s} %d\n",
+				endpoint, label("le", strconv.FormatFloat(bound, 'g', -1, 64)), cumulative)
+		}
+		fmt.Fprintf(bw, "groupie_api_request_duration_seconds_bucket{%s,%s} %d\n", endpoint, label("le", "+Inf"), e.LatencyCount)
+		fmt.Fprintf(bw, "groupie_api_request_duration_seconds_sum{%s} %s\n", endpoint, strconv.FormatFloat(e.LatencySum.Seconds(), 'g', -1, 64))
+		fmt.Fprintf(bw, "groupie_api_request_duration_seconds_count{%s} %d\n", endpoint, e.LatencyCount)
+	}
+
+	return bw.Flush()
+}
+
+// Handler retourne un handler HTTP servant les statistiques (à monter sur /metrics)
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := m.WritePrometheus(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
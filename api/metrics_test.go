@@ -0,0 +1,73 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	m := NewMetrics()
+	latencies := []time.Duration{10 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond, 3 * time.Second, time.Minute}
+	for i, latency := range latencies {
+		m.observeRequest(fmt.Sprintf("/artists/%d", i), latency)
+	}
+	m.observeBytes("/artists", 1234)
+	m.observeError("/artists/9", fmt.Errorf("artiste 9: %w", ErrNotFound))
+	m.observeRequest("/a\"b\\c\nd", time.Millisecond)
+
+	var out bytes.Buffer
+	if err := m.WritePrometheus(&out); err != nil {
+		t.Fatal(err)
+	}
+	text := out.String()
+
+	want := []string{
+		`groupie_api_requests_total{endpoint="/artists"} 5`,
+		`groupie_api_errors_total{endpoint="/artists",category="not_found"} 1`,
+		`groupie_api_response_bytes_total{endpoint="/artists"} 1234`,
+		`groupie_api_request_duration_seconds_bucket{endpoint="/artists",le="0.05"} 1`,
+		`groupie_api_request_duration_seconds_bucket{endpoint="/artists",le="0.1"} 1`,
+		`groupie_api_request_duration_seconds_bucket{endpoint="/artists",le="0.25"} 3`,
+		`groupie_api_request_duration_seconds_bucket{endpoint="/artists",le="2.5"} 3`,
+		`groupie_api_request_duration_seconds_bucket{endpoint="/artists",le="5"} 4`,
+		`groupie_api_request_duration_seconds_bucket{endpoint="/artists",le="30"} 4`,
+		`groupie_api_request_duration_seconds_bucket{endpoint="/artists",le="+Inf"} 5`,
+		`groupie_api_request_duration_seconds_sum{endpoint="/artists"} 63.41`,
+		`groupie_api_request_duration_seconds_count{endpoint="/artists"} 5`,
+		// Seuls \, " et le saut de ligne sont échappés
+		`groupie_api_requests_total{endpoint="/a\"b\\c\nd"} 1`,
+	}
+	for _, line := range want {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("ligne absente: %s\nsortie:\n%s", line, text)
+		}
+	}
+
+	// Chaque requête compte dans une seule tranche ; celle d'une minute dépasse la dernière borne
+	for _, e := range m.Snapshot() {
+		if e.Endpoint != "/artists" {
+			continue
+		}
+		var total int64
+		for _, n := range e.LatencyBuckets {
+			total += n
+		}
+		if total != 4 || e.LatencyCount != 5 {
+			t.Errorf("tranches = %v (total %d) pour %d requêtes, attendu 4 sur 5", e.LatencyBuckets, total, e.LatencyCount)
+		}
+	}
+}
+
+func TestWritePrometheusNil(t *testing.T) {
+	var m *Metrics
+	var out bytes.Buffer
+	if err := m.WritePrometheus(&out); err != nil {
+		t.Fatalf("WritePrometheus() sur un collecteur nil: %v", err)
+	}
+	if strings.Contains(out.String(), "{") {
+		t.Errorf("collecteur nil: séries inattendues\n%s", out.String())
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Endpoints de l'API Groupie Tracker
+const (
+	EndpointArtists   = "/artists"
+	EndpointLocations = "/locations"
+	EndpointDates     = "/dates"
+	EndpointRelations = "/relation"
+)
+
+// endpointLabels donne le libellé (et l'accord) utilisé dans les résumés
+var endpointLabels = map[string]struct {
+	name     string
+	feminine bool
+}{
+	EndpointArtists:   {"artistes", false},
+	EndpointRelations: {"relations", true},
+	EndpointLocations: {"lieux", false},
+	EndpointDates:     {"dates", true},
+}
+
+// EndpointReport décrit le résultat du chargement d'un endpoint
+type EndpointReport struct {
+	Endpoint string
+	Required bool // Un échec sur cet endpoint fait échouer tout le chargement
+	Err      error
+	Duration time.Duration
+	Count    int
+	Breaker  BreakerState // État du disjoncteur après le chargement
+	Cache    CacheStatus  // Origine des données (réseau ou cache disque)
+}
+
+// OK indique si l'endpoint a été chargé sans erreur
+func (r EndpointReport) OK() bool {
+	return r.Err == nil
+}
+
+// Label retourne le nom lisible de l'endpoint
+func (r EndpointReport) Label() string {
+	if label, ok := endpointLabels[r.Endpoint]; ok {
+		return label.name
+	}
+	return r.Endpoint
+}
+
+// Status retourne l'état de l'endpoint sous forme lisible ("dates indisponibles")
+func (r EndpointReport) Status() string {
+	label := endpointLabels[r.Endpoint]
+	if !r.OK() {
+		return r.Label() + " indisponibles"
+	}
+
+	status := r.Label() + " chargés"
+	if label.feminine {
+		status = r.Label() + " chargées"
+	}
+	if r.Cache.FromCache {
+		status += " (" + r.Cache.String() + ")"
+	}
+	return status
+}
+
+// LoadReport résume le chargement de tous les endpoints
+type LoadReport struct {
+	Endpoints []EndpointReport
+	Duration  time.Duration
+}
+
+// Endpoint retourne le rapport d'un endpoint donné
+func (r *LoadReport) Endpoint(endpoint string) (EndpointReport, bool) {
+	for _, e := range r.Endpoints {
+		if e.Endpoint == endpoint {
+			return e, true
+		}
+	}
+	return EndpointReport{}, false
+}
+
+// Failed retourne les endpoints en échec
+func (r *LoadReport) Failed() []EndpointReport {
+	var failed []EndpointReport
+	for _, e := range r.Endpoints {
+		if !e.OK() {
+			failed = append(failed, e)
+		}
+	}
+	return failed
+}
+
+// Complete indique si tous les endpoints ont été chargés
+func (r *LoadReport) Complete() bool {
+	return len(r.Failed()) == 0
+}
+
+// FromCache indique si au moins un endpoint a été servi depuis le cache disque
+func (r *LoadReport) FromCache() bool {
+	for _, e := range r.Endpoints {
+		if e.OK() && e.Cache.FromCache {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary retourne un résumé court, par exemple "relations chargées, dates indisponibles"
+func (r *LoadReport) Summary() string {
+	parts := make([]string, 0, len(r.Endpoints))
+	for _, e := range r.Endpoints {
+		parts = append(parts, e.Status())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// String retourne le détail de chaque endpoint (durée, nombre d'éléments, erreur)
+func (r *LoadReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Chargement en %s\n", r.Duration.Round(time.Millisecond))
+	for _, e := range r.Endpoints {
+		if e.OK() {
+			fmt.Fprintf(&sb, "  ✅ %-10s %4d éléments en %s (%s)\n", e.Endpoint, e.Count, e.Duration.Round(time.Millisecond), e.Cache)
+		} else {
+			fmt.Fprintf(&sb, "  ❌ %-10s échec en %s (circuit %s): %v\n", e.Endpoint, e.Duration.Round(time.Millisecond), e.Breaker, e.Err)
+		}
+	}
+	return sb.String()
+}
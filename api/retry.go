@@ -0,0 +1,91 @@
+package api
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy définit comment les requêtes GET en échec sont relancées
+type RetryPolicy struct {
+	MaxAttempts int           // Nombre total de tentatives (1 = aucune relance)
+	BaseDelay   time.Duration // Délai avant la première relance, doublé à chaque tentative
+	MaxDelay    time.Duration // Délai maximal entre deux tentatives (Retry-After compris)
+}
+
+// DefaultRetryPolicy absorbe le réveil de l'API hébergée sur Heroku
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// NoRetry désactive les relances
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// WithRetry définit la politique de relance des requêtes
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		if policy.MaxAttempts < 1 {
+			policy.MaxAttempts = 1
+		}
+		c.retry = policy
+	}
+}
+
+// backoff calcule le délai avant la tentative suivante (exponentiel avec jitter)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	// MaxDelay <= 0 signifie "pas de maximum" : le délai double sans plafond
+	// (en s'arrêtant avant de dépasser la capacité d'un time.Duration)
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay) && delay <= math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Jitter : délai aléatoire entre la moitié et la totalité du délai calculé
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// retryableStatus indique si un code HTTP justifie une nouvelle tentative
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter lit l'en-tête Retry-After (en secondes ou date HTTP)
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"groupie-tracker/fakeapi"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		want    time.Duration // délai avant jitter : le résultat est dans [want/2, want]
+	}{
+		{"première relance", RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}, 1, time.Second},
+		{"doublé", RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}, 3, 4 * time.Second},
+		{"plafonné", RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}, 6, 10 * time.Second},
+		{"sans maximum, première relance", RetryPolicy{BaseDelay: time.Second}, 1, time.Second},
+		{"sans maximum, doublé", RetryPolicy{BaseDelay: time.Second}, 4, 8 * time.Second},
+		{"sans maximum, longue série", RetryPolicy{BaseDelay: time.Second}, 11, 1024 * time.Second},
+		{"maximum négatif", RetryPolicy{BaseDelay: time.Second, MaxDelay: -1}, 3, 4 * time.Second},
+		{"sans délai", RetryPolicy{}, 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				got := tt.policy.backoff(tt.attempt)
+				if got < tt.want/2 || got > tt.want {
+					t.Fatalf("backoff(%d) = %v, attendu entre %v et %v", tt.attempt, got, tt.want/2, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoffNoOverflow(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second}
+	if got := policy.backoff(200); got <= 0 {
+		t.Fatalf("backoff(200) = %v, attendu un délai positif", got)
+	}
+}
+
+// flakyServer répond 503 aux failures premières requêtes, puis sert le faux serveur
+type flakyServer struct {
+	next     http.Handler
+	failures int64
+	requests atomic.Int64
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.requests.Add(1) <= s.failures {
+		http.Error(w, "indisponible", http.StatusServiceUnavailable)
+		return
+	}
+	s.next.ServeHTTP(w, r)
+}
+
+func newFlakyClient(t *testing.T, failures int64, opts ...Option) (*Client, *flakyServer) {
+	t.Helper()
+	flaky := &flakyServer{next: fakeapi.New(nil, fakeapi.Options{}), failures: failures}
+	srv := httptest.NewServer(flaky)
+	t.Cleanup(srv.Close)
+	return NewClient(append([]Option{WithBaseURL(srv.URL + fakeapi.Prefix)}, opts...)...), flaky
+}
+
+func TestRetry(t *testing.T) {
+	fast := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	tests := []struct {
+		name         string
+		failures     int64
+		policy       RetryPolicy
+		wantErr      error
+		wantRequests int64
+	}{
+		{"sans échec", 0, fast, nil, 1},
+		{"rétabli après deux échecs", 2, fast, nil, 3},
+		{"abandon après toutes les tentatives", 10, fast, ErrHTTP, 3},
+		{"sans relance", 1, NoRetry, ErrHTTP, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, flaky := newFlakyClient(t, tt.failures, WithRetry(tt.policy), WithCircuitBreaker(BreakerSettings{}))
+
+			_, err := client.GetArtistsContext(context.Background())
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("GetArtists() = %v, attendu sans erreur", err)
+			}
+			if tt.wantErr != nil {
+				var apiErr *APIError
+				if !errors.Is(err, tt.wantErr) || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+					t.Fatalf("GetArtists() = %v, attendu %v (503)", err, tt.wantErr)
+				}
+			}
+			if got := flaky.requests.Load(); got != tt.wantRequests {
+				t.Errorf("%d requête(s) envoyée(s), attendu %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	settings := BreakerSettings{FailureThreshold: 2, Cooldown: 50 * time.Millisecond}
+	client, flaky := newFlakyClient(t, 2, WithRetry(NoRetry), WithCircuitBreaker(settings))
+	ctx := context.Background()
+
+	steps := []struct {
+		name         string
+		wait         time.Duration
+		wantErr      error
+		wantState    BreakerState
+		wantRequests int64
+	}{
+		{"premier échec", 0, ErrHTTP, BreakerClosed, 1},
+		{"seuil atteint", 0, ErrHTTP, BreakerOpen, 2},
+		{"circuit ouvert, pas d'appel réseau", 0, ErrCircuitOpen, BreakerOpen, 2},
+		{"essai après refroidissement", settings.Cooldown, nil, BreakerClosed, 3},
+	}
+
+	for _, step := range steps {
+		time.Sleep(step.wait)
+		_, err := client.GetArtistsContext(ctx)
+		switch {
+		case step.wantErr == nil && err != nil:
+			t.Fatalf("%s: %v", step.name, err)
+		case step.wantErr != nil && !errors.Is(err, step.wantErr):
+			t.Fatalf("%s: %v, attendu %v", step.name, err, step.wantErr)
+		}
+		if got := client.BreakerState(EndpointArtists); got != step.wantState {
+			t.Errorf("%s: disjoncteur %v, attendu %v", step.name, got, step.wantState)
+		}
+		if got := flaky.requests.Load(); got != step.wantRequests {
+			t.Errorf("%s: %d requête(s), attendu %d", step.name, got, step.wantRequests)
+		}
+	}
+}
@@ -104,6 +104,6 @@ echo Verification terminee avec succes!
 echo ====================================
 echo.
 echo Pour compiler: go build -o groupie-tracker.exe
-echo Pour lancer: go run main.go
+echo Pour lancer: go run .
 echo.
 pause
\ No newline at end of file
@@ -0,0 +1,52 @@
+// Commande fakeapi : lance un faux serveur Groupie Tracker en local
+// pour faire tourner l'application sans accès à groupietrackers.herokuapp.com.
+package main
+
+import (
+	"flag"
+	"groupie-tracker/fakeapi"
+	"groupie-tracker/models"
+	"groupie-tracker/snapshot"
+	"log"
+	"net/http"
+	"strings"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "adresse d'écoute")
+	dataFile := flag.String("data", "", "instantané à servir (jeu de données embarqué par défaut)")
+	latency := flag.Duration("latency", 0, "délai ajouté avant chaque réponse")
+	errorRate := flag.Float64("error-rate", 0, "probabilité (0 à 1) de répondre 503")
+	malformedRate := flag.Float64("malformed-rate", 0, "probabilité (0 à 1) de renvoyer un JSON tronqué")
+	missing := flag.String("missing", "", "endpoints qui répondent 404, séparés par des virgules (ex: dates,locations)")
+	seed := flag.Int64("seed", 0, "graine du tirage aléatoire (0 = selon l'heure)")
+	flag.Parse()
+
+	var data *models.APIData
+	if *dataFile != "" {
+		snap, err := snapshot.ReadFile(*dataFile)
+		if err != nil {
+			log.Fatalf("❌ %v\n", err)
+		}
+		data = snap.Data
+	}
+
+	opts := fakeapi.Options{
+		Latency:       *latency,
+		ErrorRate:     *errorRate,
+		MalformedRate: *malformedRate,
+		Seed:          *seed,
+	}
+	if *missing != "" {
+		opts.Missing = strings.Split(*missing, ",")
+	}
+
+	server := fakeapi.New(data, opts)
+
+	log.Printf("🎸 Faux serveur Groupie Tracker: %d artistes sur http://%s%s\n", len(server.Data().Artists), *addr, fakeapi.Prefix)
+	log.Printf("💡 Lancez l'application avec: go run . -api-url http://%s%s\n", *addr, fakeapi.Prefix)
+
+	if err := http.ListenAndServe(*addr, server); err != nil {
+		log.Fatalf("❌ %v\n", err)
+	}
+}
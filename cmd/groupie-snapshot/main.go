@@ -0,0 +1,44 @@
+// Commande groupie-snapshot : enregistre un instantané de l'API Groupie Tracker
+// pour utiliser l'application sans connexion (go run . -snapshot fichier.json.gz).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"groupie-tracker/api"
+	"groupie-tracker/snapshot"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	output := flag.String("o", "groupie-snapshot.json.gz", "fichier de sortie (compressé si l'extension est .gz)")
+	apiURL := flag.String("api-url", api.BaseURL, "URL de base de l'API Groupie Tracker")
+	timeout := flag.Duration("timeout", 2*time.Minute, "durée maximale du chargement complet")
+	flag.Parse()
+
+	client := api.NewClient(api.WithBaseURL(*apiURL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	log.Printf("🔄 Chargement des données depuis %s...\n", client.BaseURL())
+	data, report, err := client.LoadAllDataWithReport(ctx)
+	if err != nil {
+		log.Fatalf("❌ Erreur lors du chargement: %v\n%s", err, report)
+	}
+	log.Print(report)
+
+	if !report.Complete() {
+		fmt.Fprintf(os.Stderr, "⚠️ Instantané incomplet: %s\n", report.Summary())
+	}
+
+	snap := snapshot.New(data, client.BaseURL())
+	if err := snapshot.WriteFile(*output, snap); err != nil {
+		log.Fatalf("❌ %v\n", err)
+	}
+
+	log.Printf("✅ Instantané écrit dans %s (%d artistes)\n", *output, len(data.Artists))
+}
@@ -0,0 +1,55 @@
+// Commande groupie-validate : contrôle la qualité des données de l'API Groupie Tracker
+// (ou d'un instantané) et affiche les erreurs et avertissements par entité.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"groupie-tracker/api"
+	"groupie-tracker/datasource"
+	"groupie-tracker/services"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	apiURL := flag.String("api-url", api.BaseURL, "URL de base de l'API Groupie Tracker")
+	snapshotPath := flag.String("snapshot", "", "contrôler un instantané au lieu de l'API")
+	timeout := flag.Duration("timeout", 2*time.Minute, "durée maximale du chargement complet")
+	errorsOnly := flag.Bool("errors-only", false, "n'afficher que les erreurs")
+	flag.Parse()
+
+	var source datasource.DataSource
+	if *snapshotPath != "" {
+		source = datasource.NewFile(*snapshotPath)
+	} else {
+		source = datasource.NewHTTP(api.NewClient(api.WithBaseURL(*apiURL)))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	log.Printf("🔄 Chargement des données depuis %s...\n", source.Name())
+	data, err := source.Load(ctx)
+	if err != nil {
+		log.Fatalf("❌ Erreur lors du chargement: %v\n", err)
+	}
+
+	report := services.Validate(data)
+
+	findings := report.Findings
+	if *errorsOnly {
+		findings = report.Errors()
+	}
+
+	fmt.Println(report.Summary())
+	for _, finding := range findings {
+		fmt.Println("  " + finding.String())
+	}
+
+	if !report.OK() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"groupie-tracker/api"
+	"groupie-tracker/datasource"
+	"groupie-tracker/fuzzy"
+	"groupie-tracker/images"
+	"groupie-tracker/overrides"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Variables d'environnement reconnues (les flags de la ligne de commande sont prioritaires)
+const (
+	envAPIURL    = "GROUPIE_API_URL"
+	envTimeout   = "GROUPIE_API_TIMEOUT"
+	envUserAgent = "GROUPIE_USER_AGENT"
+	envHeaders   = "GROUPIE_API_HEADERS"
+	envSnapshot  = "GROUPIE_SNAPSHOT"
+	envRefresh   = "GROUPIE_REFRESH_INTERVAL"
+	envOverrides = "GROUPIE_OVERRIDES"
+)
+
+// Config regroupe les options de démarrage de l'application
+type Config struct {
+	APIURL    string
+	Timeout   time.Duration
+	UserAgent string
+	Headers   headerList
+	Retry     api.RetryPolicy
+	Breaker   api.BreakerSettings
+	CacheDir  string
+	CacheAge  time.Duration
+	NoCache   bool
+	Snapshot  string
+	Overrides string
+	MaxSize   int64
+	Strict    bool
+	Refresh   time.Duration
+	Metrics   string
+	Record    string
+	Replay    string
+
+	ImageDir       string
+	ImageCacheSize int64
+	ImageWorkers   int
+	NoImages       bool
+
+	SearchTypos int // Fautes de frappe tolérées par la recherche (0 = recherche exacte)
+
+	// Cassette partagée par le client API et le téléchargement des images
+	recorder *api.Recorder
+	replayer *api.Replayer
+}
+
+// headerList accumule les flags -header répétés ("Clé: Valeur")
+type headerList []string
+
+func (h *headerList) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headerList) Set(value string) error {
+	if _, _, ok := splitHeader(value); !ok {
+		return fmt.Errorf("en-tête invalide %q (format attendu \"Clé: Valeur\")", value)
+	}
+	*h = append(*h, value)
+	return nil
+}
+
+// splitHeader découpe un en-tête au format "Clé: Valeur"
+func splitHeader(header string) (string, string, bool) {
+	key, value, ok := strings.Cut(header, ":")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(value), true
+}
+
+// parseConfig lit la configuration depuis l'environnement puis les flags
+func parseConfig(args []string) (*Config, error) {
+	cfg := &Config{
+		APIURL:    api.BaseURL,
+		Timeout:   api.DefaultTimeout,
+		UserAgent: api.DefaultUserAgent,
+		Retry:     api.DefaultRetryPolicy,
+		Breaker:   api.DefaultBreakerSettings,
+		CacheAge:  api.DefaultCacheMaxAge,
+		MaxSize:   api.DefaultMaxResponseSize,
+
+		ImageCacheSize: images.DefaultMaxCacheSize,
+		ImageWorkers:   images.DefaultWorkers,
+
+		SearchTypos: fuzzy.DefaultTolerance.Max,
+	}
+
+	if dir, err := api.DefaultCacheDir(); err == nil {
+		cfg.CacheDir = dir
+	}
+	if dir, err := images.DefaultCacheDir(); err == nil {
+		cfg.ImageDir = dir
+	}
+
+	if v := os.Getenv(envAPIURL); v != "" {
+		cfg.APIURL = v
+	}
+	if v := os.Getenv(envTimeout); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s invalide: %w", envTimeout, err)
+		}
+		cfg.Timeout = timeout
+	}
+	if v := os.Getenv(envUserAgent); v != "" {
+		cfg.UserAgent = v
+	}
+	if v := os.Getenv(envSnapshot); v != "" {
+		cfg.Snapshot = v
+	}
+	if v := os.Getenv(envOverrides); v != "" {
+		cfg.Overrides = v
+	}
+	if v := os.Getenv(envRefresh); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s invalide: %w", envRefresh, err)
+		}
+		cfg.Refresh = interval
+	}
+	if v := os.Getenv(envHeaders); v != "" {
+		// Plusieurs en-têtes séparés par ";"
+		for _, header := range strings.Split(v, ";") {
+			if strings.TrimSpace(header) == "" {
+				continue
+			}
+			if err := cfg.Headers.Set(header); err != nil {
+				return nil, fmt.Errorf("%s: %w", envHeaders, err)
+			}
+		}
+	}
+
+	fs := flag.NewFlagSet("groupie-tracker", flag.ContinueOnError)
+	fs.StringVar(&cfg.APIURL, "api-url", cfg.APIURL, "URL de base de l'API Groupie Tracker")
+	fs.DurationVar(&cfg.Timeout, "timeout", cfg.Timeout, "durée maximale d'une requête (0 = illimitée)")
+	fs.StringVar(&cfg.UserAgent, "user-agent", cfg.UserAgent, "en-tête User-Agent envoyé à l'API")
+	fs.Var(&cfg.Headers, "header", "en-tête supplémentaire \"Clé: Valeur\" (répétable)")
+	fs.IntVar(&cfg.Retry.MaxAttempts, "retries", cfg.Retry.MaxAttempts, "nombre total de tentatives par requête (1 = aucune relance)")
+	fs.DurationVar(&cfg.Retry.BaseDelay, "retry-delay", cfg.Retry.BaseDelay, "délai avant la première relance (doublé ensuite)")
+	fs.DurationVar(&cfg.Retry.MaxDelay, "retry-max-delay", cfg.Retry.MaxDelay, "délai maximal entre deux tentatives")
+	fs.IntVar(&cfg.Breaker.FailureThreshold, "breaker-threshold", cfg.Breaker.FailureThreshold, "échecs consécutifs avant de couper un endpoint (0 = désactivé)")
+	fs.DurationVar(&cfg.Breaker.Cooldown, "breaker-cooldown", cfg.Breaker.Cooldown, "durée de coupure d'un endpoint avant un nouvel essai")
+	fs.StringVar(&cfg.CacheDir, "cache-dir", cfg.CacheDir, "dossier du cache disque des réponses de l'API")
+	fs.DurationVar(&cfg.CacheAge, "cache-max-age", cfg.CacheAge, "validité des réponses en cache sans ETag ni Last-Modified")
+	fs.BoolVar(&cfg.NoCache, "no-cache", false, "désactiver le cache disque")
+	fs.Int64Var(&cfg.MaxSize, "max-response-size", cfg.MaxSize, "taille maximale d'une réponse en octets (0 = illimitée)")
+	fs.BoolVar(&cfg.Strict, "strict-json", false, "refuser les champs inconnus et les données après le JSON")
+	fs.StringVar(&cfg.Snapshot, "snapshot", cfg.Snapshot, "charger les données depuis un instantané au lieu de l'API")
+	fs.StringVar(&cfg.ImageDir, "image-cache-dir", cfg.ImageDir, "dossier du cache des images des artistes")
+	fs.Int64Var(&cfg.ImageCacheSize, "image-cache-size", cfg.ImageCacheSize, "taille maximale du cache des images en octets (0 = illimitée)")
+	fs.IntVar(&cfg.ImageWorkers, "image-workers", cfg.ImageWorkers, "nombre de téléchargements d'images simultanés")
+	fs.BoolVar(&cfg.NoImages, "no-images", false, "ne pas afficher les images des artistes")
+	fs.IntVar(&cfg.SearchTypos, "search-typos", cfg.SearchTypos, "nombre maximal de fautes de frappe tolérées par la recherche (0 = recherche exacte)")
+	fs.StringVar(&cfg.Overrides, "overrides", cfg.Overrides, "fichier de corrections locales (JSON ou TOML) appliquées aux données")
+	fs.StringVar(&cfg.Record, "record", "", "enregistrer toutes les requêtes à l'API dans une cassette (écrite à la fermeture)")
+	fs.StringVar(&cfg.Replay, "replay", "", "rejouer une cassette au lieu d'interroger l'API")
+	fs.StringVar(&cfg.Metrics, "metrics-addr", "", "adresse d'écoute locale pour /metrics au format Prometheus (ex. localhost:9090)")
+	fs.DurationVar(&cfg.Refresh, "refresh-interval", cfg.Refresh, "intervalle de rechargement automatique des données (0 = désactivé)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if cfg.Record != "" && cfg.Replay != "" {
+		return nil, fmt.Errorf("-record et -replay ne peuvent pas être utilisés ensemble")
+	}
+	if cfg.SearchTypos < 0 {
+		return nil, fmt.Errorf("-search-typos doit être positif ou nul")
+	}
+
+	switch {
+	case cfg.Record != "":
+		cfg.recorder = api.NewRecorder(cfg.Record, nil)
+	case cfg.Replay != "":
+		replayer, err := api.LoadCassette(cfg.Replay)
+		if err != nil {
+			return nil, err
+		}
+		cfg.replayer = replayer
+	}
+
+	return cfg, nil
+}
+
+// clientOptions convertit la configuration en options pour api.NewClient
+func (cfg *Config) clientOptions() []api.Option {
+	opts := []api.Option{
+		api.WithBaseURL(cfg.APIURL),
+		api.WithTimeout(cfg.Timeout),
+		api.WithUserAgent(cfg.UserAgent),
+		api.WithRetry(cfg.Retry),
+		api.WithCircuitBreaker(cfg.Breaker),
+		api.WithMaxResponseSize(cfg.MaxSize),
+	}
+
+	if cfg.Strict {
+		opts = append(opts, api.WithDecodeMode(api.StrictDecoding))
+	}
+
+	// Le cache disque est ignoré pour que la cassette contienne (ou serve) chaque requête
+	switch {
+	case cfg.recorder != nil:
+		opts = append(opts, api.WithRecorder(cfg.recorder))
+	case cfg.replayer != nil:
+		opts = append(opts, api.WithReplay(cfg.replayer))
+	case !cfg.NoCache:
+		opts = append(opts, api.WithCache(cfg.CacheDir, cfg.CacheAge))
+	}
+
+	for _, header := range cfg.Headers {
+		key, value, _ := splitHeader(header)
+		opts = append(opts, api.WithHeader(key, value))
+	}
+
+	return opts
+}
+
+// dataSource construit la source de données correspondant à la configuration
+func (cfg *Config) dataSource() (datasource.DataSource, error) {
+	var source datasource.DataSource
+	if cfg.Snapshot != "" {
+		source = datasource.NewFile(cfg.Snapshot)
+	} else {
+		source = datasource.NewHTTP(api.NewClient(cfg.clientOptions()...))
+	}
+
+	if cfg.Overrides != "" {
+		o, err := overrides.Load(cfg.Overrides)
+		if err != nil {
+			return nil, err
+		}
+		source = datasource.WithOverrides(source, o)
+	}
+
+	return source, nil
+}
+
+// imageService construit le service d'images (nil si les images sont désactivées)
+func (cfg *Config) imageService() *images.Service {
+	if cfg.NoImages {
+		return nil
+	}
+
+	opts := []images.Option{
+		images.WithMaxCacheSize(cfg.ImageCacheSize),
+		images.WithWorkers(cfg.ImageWorkers),
+	}
+
+	// Les images passent par la cassette : enregistrées avec -record, servies sans réseau avec -replay
+	var transport http.RoundTripper
+	switch {
+	case cfg.recorder != nil:
+		transport = cfg.recorder
+	case cfg.replayer != nil:
+		transport = cfg.replayer
+	}
+	if transport != nil {
+		opts = append(opts, images.WithHTTPClient(&http.Client{Transport: transport, Timeout: images.DefaultTimeout}))
+	}
+
+	return images.NewService(cfg.ImageDir, opts...)
+}
+
+// closeRecorder écrit la cassette enregistrée avec -record
+func (cfg *Config) closeRecorder() {
+	if cfg.recorder == nil {
+		return
+	}
+	if err := cfg.recorder.Close(); err != nil {
+		log.Printf("❌ %v\n", err)
+		return
+	}
+	log.Printf("📼 Cassette %s enregistrée (%d requêtes)\n", cfg.Record, cfg.recorder.Len())
+}
+
+// searchTolerance retourne la tolérance aux fautes de frappe de la recherche
+func (cfg *Config) searchTolerance() fuzzy.Tolerance {
+	tolerance := fuzzy.DefaultTolerance
+	tolerance.Max = cfg.SearchTypos
+	return tolerance
+}
@@ -0,0 +1,85 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+)
+
+// ErrNotFound est retournée quand une entité demandée n'existe pas
+// (identique à api.ErrNotFound pour que errors.Is fonctionne quelle que soit la source)
+var ErrNotFound = api.ErrNotFound
+
+// DataSource fournit les données de l'application, quelle que soit leur origine
+// (API HTTP, fichier d'instantané, données en mémoire...)
+type DataSource interface {
+	// Name décrit la source pour les logs
+	Name() string
+
+	// Load retourne le jeu de données complet, en le chargeant si nécessaire
+	Load(ctx context.Context) (*models.APIData, error)
+
+	// Reload recharge le jeu de données complet depuis son origine
+	Reload(ctx context.Context) (*models.APIData, error)
+
+	// Récupération d'une seule entité par son ID
+	Artist(ctx context.Context, id int) (*models.Artist, error)
+	Location(ctx context.Context, id int) (*models.Location, error)
+	Date(ctx context.Context, id int) (*models.Date, error)
+	Relation(ctx context.Context, id int) (*models.Relation, error)
+}
+
+// StatusReporter est implémentée par les sources capables de décrire leur dernier chargement
+type StatusReporter interface {
+	Status() string
+}
+
+// notFound construit une erreur ErrNotFound pour une entité
+func notFound(entity string, id int) error {
+	return fmt.Errorf("%s %d: %w", entity, id, ErrNotFound)
+}
+
+// findArtist cherche un artiste par ID dans un jeu de données
+func findArtist(data *models.APIData, id int) (*models.Artist, error) {
+	for i := range data.Artists {
+		if data.Artists[i].ID == id {
+			artist := data.Artists[i]
+			return &artist, nil
+		}
+	}
+	return nil, notFound("artiste", id)
+}
+
+// findLocation cherche les lieux d'un artiste par ID
+func findLocation(data *models.APIData, id int) (*models.Location, error) {
+	for i := range data.Locations {
+		if data.Locations[i].ID == id {
+			location := data.Locations[i]
+			return &location, nil
+		}
+	}
+	return nil, notFound("lieux", id)
+}
+
+// findDate cherche les dates d'un artiste par ID
+func findDate(data *models.APIData, id int) (*models.Date, error) {
+	for i := range data.Dates {
+		if data.Dates[i].ID == id {
+			date := data.Dates[i]
+			return &date, nil
+		}
+	}
+	return nil, notFound("dates", id)
+}
+
+// findRelation cherche la relation d'un artiste par ID
+func findRelation(data *models.APIData, id int) (*models.Relation, error) {
+	for i := range data.Relations {
+		if data.Relations[i].ID == id {
+			relation := data.Relations[i]
+			return &relation, nil
+		}
+	}
+	return nil, notFound("relation", id)
+}
@@ -0,0 +1,147 @@
+package datasource
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"groupie-tracker/api"
+	"groupie-tracker/fakeapi"
+	"groupie-tracker/snapshot"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// sources retourne une source de chaque type servant les jeux de données du faux serveur
+func sources(t *testing.T) map[string]DataSource {
+	t.Helper()
+
+	srv := httptest.NewServer(fakeapi.New(nil, fakeapi.Options{}))
+	t.Cleanup(srv.Close)
+	client := api.NewClient(api.WithBaseURL(srv.URL+fakeapi.Prefix), api.WithRetry(api.NoRetry))
+
+	path := filepath.Join(t.TempDir(), "snapshot.json.gz")
+	if err := snapshot.WriteFile(path, snapshot.New(fakeapi.Fixtures(), srv.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]DataSource{
+		"http":       NewHTTP(client),
+		"instantané": NewFile(path),
+		"mémoire":    NewMemory(fakeapi.Fixtures()),
+	}
+}
+
+func TestSources(t *testing.T) {
+	want := fakeapi.Fixtures()
+	ctx := context.Background()
+
+	for name, source := range sources(t) {
+		t.Run(name, func(t *testing.T) {
+			data, err := source.Load(ctx)
+			if err != nil {
+				t.Fatalf("Load(): %v", err)
+			}
+			if len(data.Artists) != len(want.Artists) || len(data.Relations) != len(want.Relations) {
+				t.Errorf("Load() = %d artistes et %d relations, attendu %d et %d",
+					len(data.Artists), len(data.Relations), len(want.Artists), len(want.Relations))
+			}
+			if again, _ := source.Load(ctx); again != data {
+				t.Errorf("second Load() a rechargé les données au lieu de les réutiliser")
+			}
+
+			id := want.Artists[0].ID
+			artist, err := source.Artist(ctx, id)
+			if err != nil || artist.Name != want.Artists[0].Name {
+				t.Errorf("Artist(%d) = %v, %v, attendu %q", id, artist, err, want.Artists[0].Name)
+			}
+			if _, err := source.Relation(ctx, id); err != nil {
+				t.Errorf("Relation(%d): %v", id, err)
+			}
+			if _, err := source.Location(ctx, id); err != nil {
+				t.Errorf("Location(%d): %v", id, err)
+			}
+			if _, err := source.Date(ctx, id); err != nil {
+				t.Errorf("Date(%d): %v", id, err)
+			}
+
+			if _, err := source.Artist(ctx, 9999); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Artist(9999) = %v, attendu %v", err, ErrNotFound)
+			}
+			if _, err := source.Relation(ctx, 9999); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Relation(9999) = %v, attendu %v", err, ErrNotFound)
+			}
+
+			if reporter, ok := source.(StatusReporter); ok && reporter.Status() == "" {
+				t.Errorf("Status() vide après un chargement")
+			}
+		})
+	}
+}
+
+func TestFileRejectsMalformedSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr error
+	}{
+		{"json invalide", "snapshot.json", `{"metadata": `, nil},
+		{"version inconnue", "snapshot.json", `{"metadata": {"schemaVersion": 99}, "data": {}}`, snapshot.ErrUnsupportedVersion},
+		{"sans données", "snapshot.json", `{"metadata": {"schemaVersion": 1}}`, nil},
+		{"gzip tronqué", "snapshot.json.gz", "\x1f\x8b\x08\x00", nil},
+		{"fichier absent", "absent.json", "", os.ErrNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			source := NewFile(path)
+			_, err := source.Load(context.Background())
+			if err == nil {
+				t.Fatalf("Load() sans erreur, attendu une erreur")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Load() = %v, attendu %v", err, tt.wantErr)
+			}
+			if source.Snapshot() != nil || source.Status() != "" {
+				t.Errorf("instantané retenu malgré l'erreur de lecture")
+			}
+		})
+	}
+}
+
+func TestFileReloadKeepsPreviousSnapshotOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json.gz")
+	if err := snapshot.WriteFile(path, snapshot.New(fakeapi.Fixtures(), "")); err != nil {
+		t.Fatal(err)
+	}
+	source := NewFile(path)
+	if _, err := source.Load(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Fichier remplacé par un gzip valide mais au contenu JSON invalide
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(file)
+	gz.Write([]byte(`[1, 2`))
+	gz.Close()
+	file.Close()
+
+	if _, err := source.Reload(context.Background()); err == nil {
+		t.Fatalf("Reload() sans erreur, attendu une erreur")
+	}
+	if source.Snapshot() == nil {
+		t.Errorf("Reload() en échec a oublié l'instantané précédent")
+	}
+}
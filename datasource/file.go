@@ -0,0 +1,111 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"groupie-tracker/models"
+	"groupie-tracker/snapshot"
+	"sync"
+)
+
+// File est une source de données lue depuis un fichier d'instantané
+type File struct {
+	path string
+
+	mu       sync.RWMutex
+	snapshot *snapshot.Snapshot
+}
+
+// NewFile crée une source à partir d'un fichier d'instantané
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Name décrit la source
+func (f *File) Name() string {
+	return "instantané " + f.path
+}
+
+// Status décrit l'instantané chargé
+func (f *File) Status() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.snapshot == nil {
+		return ""
+	}
+	return fmt.Sprintf("📦 Instantané du %s", f.snapshot.Metadata.FetchedAt.Local().Format("02/01/2006 15:04"))
+}
+
+// Snapshot retourne l'instantané chargé (nil avant le premier chargement)
+func (f *File) Snapshot() *snapshot.Snapshot {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.snapshot
+}
+
+// Load lit l'instantané s'il ne l'a pas encore été
+func (f *File) Load(ctx context.Context) (*models.APIData, error) {
+	f.mu.RLock()
+	snap := f.snapshot
+	f.mu.RUnlock()
+
+	if snap != nil {
+		return snap.Data, nil
+	}
+	return f.Reload(ctx)
+}
+
+// Reload relit le fichier d'instantané
+func (f *File) Reload(ctx context.Context) (*models.APIData, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	snap, err := snapshot.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.snapshot = snap
+	f.mu.Unlock()
+
+	return snap.Data, nil
+}
+
+// Artist retourne un artiste par ID
+func (f *File) Artist(ctx context.Context, id int) (*models.Artist, error) {
+	data, err := f.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findArtist(data, id)
+}
+
+// Location retourne les lieux d'un artiste par ID
+func (f *File) Location(ctx context.Context, id int) (*models.Location, error) {
+	data, err := f.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findLocation(data, id)
+}
+
+// Date retourne les dates d'un artiste par ID
+func (f *File) Date(ctx context.Context, id int) (*models.Date, error) {
+	data, err := f.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findDate(data, id)
+}
+
+// Relation retourne la relation d'un artiste par ID
+func (f *File) Relation(ctx context.Context, id int) (*models.Relation, error) {
+	data, err := f.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findRelation(data, id)
+}
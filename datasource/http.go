@@ -0,0 +1,109 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"groupie-tracker/api"
+	"groupie-tracker/models"
+	"sync"
+)
+
+// HTTP est une source de données qui interroge l'API Groupie Tracker
+type HTTP struct {
+	client *api.Client
+
+	mu     sync.RWMutex
+	data   *models.APIData
+	report *api.LoadReport
+}
+
+// NewHTTP crée une source à partir d'un client API
+func NewHTTP(client *api.Client) *HTTP {
+	return &HTTP{client: client}
+}
+
+// Name décrit la source
+func (h *HTTP) Name() string {
+	return h.client.BaseURL()
+}
+
+// Client retourne le client API utilisé par la source
+func (h *HTTP) Client() *api.Client {
+	return h.client
+}
+
+// Report retourne le rapport du dernier chargement (nil avant le premier chargement)
+func (h *HTTP) Report() *api.LoadReport {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.report
+}
+
+// Status résume le dernier chargement ("relations chargées, dates indisponibles")
+func (h *HTTP) Status() string {
+	report := h.Report()
+	if report == nil {
+		return ""
+	}
+
+	switch {
+	case !report.Complete():
+		return "⚠️ " + report.Summary()
+	case report.FromCache():
+		return "📦 " + report.Summary()
+	default:
+		return "✅ " + report.Summary()
+	}
+}
+
+// Load retourne les données déjà chargées, ou les charge depuis l'API
+func (h *HTTP) Load(ctx context.Context) (*models.APIData, error) {
+	h.mu.RLock()
+	data := h.data
+	h.mu.RUnlock()
+
+	if data != nil {
+		return data, nil
+	}
+	return h.Reload(ctx)
+}
+
+// Reload charge toutes les données depuis l'API
+func (h *HTTP) Reload(ctx context.Context) (*models.APIData, error) {
+	data, report, err := h.client.LoadAllDataWithReport(ctx)
+
+	h.mu.Lock()
+	h.report = report
+	if err == nil {
+		h.data = data
+	}
+	h.mu.Unlock()
+
+	if err != nil {
+		if report != nil {
+			return nil, fmt.Errorf("%w (%s)", err, report.Summary())
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+// Artist récupère un artiste directement sur /artists/{id}
+func (h *HTTP) Artist(ctx context.Context, id int) (*models.Artist, error) {
+	return h.client.GetArtistContext(ctx, id)
+}
+
+// Location récupère les lieux d'un artiste directement sur /locations/{id}
+func (h *HTTP) Location(ctx context.Context, id int) (*models.Location, error) {
+	return h.client.GetLocationContext(ctx, id)
+}
+
+// Date récupère les dates d'un artiste directement sur /dates/{id}
+func (h *HTTP) Date(ctx context.Context, id int) (*models.Date, error) {
+	return h.client.GetDateContext(ctx, id)
+}
+
+// Relation récupère la relation d'un artiste directement sur /relation/{id}
+func (h *HTTP) Relation(ctx context.Context, id int) (*models.Relation, error) {
+	return h.client.GetRelationContext(ctx, id)
+}
@@ -0,0 +1,81 @@
+package datasource
+
+import (
+	"context"
+	"groupie-tracker/models"
+	"sync"
+)
+
+// Memory est une source de données en mémoire (tests, données déjà chargées)
+type Memory struct {
+	mu   sync.RWMutex
+	data *models.APIData
+}
+
+// NewMemory crée une source à partir d'un jeu de données existant
+func NewMemory(data *models.APIData) *Memory {
+	if data == nil {
+		data = &models.APIData{}
+	}
+	return &Memory{data: data}
+}
+
+// Name décrit la source
+func (m *Memory) Name() string {
+	return "mémoire"
+}
+
+// Set remplace le jeu de données retourné par la source
+func (m *Memory) Set(data *models.APIData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data = data
+}
+
+// Load retourne le jeu de données
+func (m *Memory) Load(ctx context.Context) (*models.APIData, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.data, ctx.Err()
+}
+
+// Reload retourne le jeu de données (identique à Load)
+func (m *Memory) Reload(ctx context.Context) (*models.APIData, error) {
+	return m.Load(ctx)
+}
+
+// Artist retourne un artiste par ID
+func (m *Memory) Artist(ctx context.Context, id int) (*models.Artist, error) {
+	data, err := m.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findArtist(data, id)
+}
+
+// Location retourne les lieux d'un artiste par ID
+func (m *Memory) Location(ctx context.Context, id int) (*models.Location, error) {
+	data, err := m.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findLocation(data, id)
+}
+
+// Date retourne les dates d'un artiste par ID
+func (m *Memory) Date(ctx context.Context, id int) (*models.Date, error) {
+	data, err := m.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findDate(data, id)
+}
+
+// Relation retourne la relation d'un artiste par ID
+func (m *Memory) Relation(ctx context.Context, id int) (*models.Relation, error) {
+	data, err := m.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findRelation(data, id)
+}
@@ -0,0 +1,158 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"groupie-tracker/models"
+	"groupie-tracker/overrides"
+	"log"
+	"sync"
+)
+
+// Unwrapper est implémentée par les sources qui en enveloppent une autre
+type Unwrapper interface {
+	Unwrap() DataSource
+}
+
+// Overridden applique des surcharges locales aux données d'une autre source
+type Overridden struct {
+	source    DataSource
+	overrides *overrides.Overrides
+
+	mu         sync.RWMutex
+	provenance *overrides.Provenance
+}
+
+// WithOverrides enveloppe une source pour lui appliquer des surcharges
+func WithOverrides(source DataSource, o *overrides.Overrides) *Overridden {
+	return &Overridden{source: source, overrides: o}
+}
+
+// Name décrit la source
+func (o *Overridden) Name() string {
+	return fmt.Sprintf("%s (surcharges %s)", o.source.Name(), o.overrides.Path())
+}
+
+// Unwrap retourne la source enveloppée
+func (o *Overridden) Unwrap() DataSource {
+	return o.source
+}
+
+// Status décrit la source enveloppée et le nombre d'artistes surchargés
+func (o *Overridden) Status() string {
+	var status string
+	if reporter, ok := o.source.(StatusReporter); ok {
+		status = reporter.Status()
+	}
+
+	provenance := o.Provenance()
+	if provenance == nil {
+		return status
+	}
+
+	note := fmt.Sprintf("✏️ %d artiste(s) corrigé(s) localement", provenance.Artists())
+	if status == "" {
+		return note
+	}
+	return status + "\n" + note
+}
+
+// Provenance indique les valeurs surchargées du dernier chargement (nil avant)
+func (o *Overridden) Provenance() *overrides.Provenance {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.provenance
+}
+
+// Load retourne les données de la source avec les surcharges appliquées
+func (o *Overridden) Load(ctx context.Context) (*models.APIData, error) {
+	data, err := o.source.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return o.apply(data), nil
+}
+
+// Reload recharge la source puis applique les surcharges
+func (o *Overridden) Reload(ctx context.Context) (*models.APIData, error) {
+	data, err := o.source.Reload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return o.apply(data), nil
+}
+
+// apply fusionne les surcharges et retient leur provenance
+func (o *Overridden) apply(data *models.APIData) *models.APIData {
+	merged, provenance := o.overrides.Apply(data)
+	for _, warning := range provenance.Warnings {
+		log.Printf("⚠️ Surcharges: %s\n", warning)
+	}
+
+	o.mu.Lock()
+	o.provenance = provenance
+	o.mu.Unlock()
+
+	return merged
+}
+
+// Artist retourne un artiste avec ses surcharges (y compris un artiste absent de la source)
+func (o *Overridden) Artist(ctx context.Context, id int) (*models.Artist, error) {
+	artist, err := o.source.Artist(ctx, id)
+	if err == nil {
+		return o.overrides.ApplyArtist(artist), nil
+	}
+	if !errors.Is(err, ErrNotFound) || !o.overrides.Has(id) {
+		return nil, err
+	}
+
+	data, err := o.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findArtist(data, id)
+}
+
+// Location retourne les lieux d'un artiste, recalculés s'il a des surcharges
+func (o *Overridden) Location(ctx context.Context, id int) (*models.Location, error) {
+	if !o.overrides.Has(id) {
+		return o.source.Location(ctx, id)
+	}
+
+	data, err := o.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findLocation(data, id)
+}
+
+// Date retourne les dates d'un artiste, recalculées s'il a des surcharges
+func (o *Overridden) Date(ctx context.Context, id int) (*models.Date, error) {
+	if !o.overrides.Has(id) {
+		return o.source.Date(ctx, id)
+	}
+
+	data, err := o.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findDate(data, id)
+}
+
+// Relation retourne la relation d'un artiste avec ses concerts surchargés
+func (o *Overridden) Relation(ctx context.Context, id int) (*models.Relation, error) {
+	relation, err := o.source.Relation(ctx, id)
+	if err == nil {
+		return o.overrides.ApplyRelation(relation), nil
+	}
+	if !errors.Is(err, ErrNotFound) || !o.overrides.Has(id) {
+		return nil, err
+	}
+
+	data, err := o.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findRelation(data, id)
+}
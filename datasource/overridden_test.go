@@ -0,0 +1,86 @@
+package datasource
+
+import (
+	"context"
+	"groupie-tracker/models"
+	"groupie-tracker/overrides"
+	"testing"
+)
+
+func TestOverriddenReappliesOnEachLoad(t *testing.T) {
+	o, err := overrides.Parse([]byte(`
+[artists.1]
+name = "Queen (corrigé)"
+
+[artists.1.addConcerts]
+"paris-france" = ["02-02-2020"]
+
+[artists.5]
+name = "Artiste local"
+`), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := dataset(map[string][]string{"london-uk": {"01-01-2020"}})
+	memory := NewMemory(first)
+	source := WithOverrides(memory, o)
+	ctx := context.Background()
+
+	steps := []struct {
+		name string
+		data *models.APIData
+		load func(context.Context) (*models.APIData, error)
+	}{
+		{"Load", first, source.Load},
+		{"Reload après mise à jour de la source", dataset(map[string][]string{"berlin-germany": {"03-03-2020"}}), source.Reload},
+		{"Load après mise à jour de la source", dataset(map[string][]string{"rome-italy": {"04-04-2020"}}), source.Load},
+	}
+
+	for _, step := range steps {
+		memory.Set(step.data)
+		data, err := step.load(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+
+		if data.Artists[0].Name != "Queen (corrigé)" {
+			t.Errorf("%s: nom = %q, surcharge non appliquée", step.name, data.Artists[0].Name)
+		}
+		if len(data.Artists) != 2 || data.Artists[1].ID != 5 {
+			t.Errorf("%s: artistes = %+v, attendu l'artiste local 5 ajouté", step.name, data.Artists)
+		}
+		concerts := data.Relations[0].DatesLocations
+		if len(concerts["paris-france"]) != 1 || len(concerts) != 2 {
+			t.Errorf("%s: concerts = %v, attendu ceux de la source plus paris-france", step.name, concerts)
+		}
+
+		// La source n'est jamais modifiée par les surcharges
+		if step.data.Artists[0].Name != "Queen" || len(step.data.Relations[0].DatesLocations) != 1 {
+			t.Errorf("%s: données de la source modifiées: %+v", step.name, step.data)
+		}
+		if prov := source.Provenance(); !prov.Overridden(1, overrides.FieldName) || !prov.Added(5) {
+			t.Errorf("%s: provenance = %v, attendu le nom de 1 et l'artiste 5", step.name, prov)
+		}
+	}
+}
+
+func TestOverriddenEntities(t *testing.T) {
+	o, err := overrides.Parse([]byte(`{"artists": {"1": {"addMembers": ["Brian May"]}, "5": {"name": "Artiste local"}}}`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := WithOverrides(NewMemory(dataset(map[string][]string{"london-uk": {"01-01-2020"}})), o)
+	ctx := context.Background()
+
+	artist, err := source.Artist(ctx, 1)
+	if err != nil || len(artist.Members) != 2 {
+		t.Errorf("Artist(1) = %+v, %v, attendu le membre ajouté", artist, err)
+	}
+	if artist, err := source.Artist(ctx, 5); err != nil || artist.Name != "Artiste local" {
+		t.Errorf("Artist(5) = %+v, %v, attendu l'artiste absent de la source", artist, err)
+	}
+	if _, err := source.Relation(ctx, 5); err != nil {
+		t.Errorf("Relation(5): %v, attendu une relation vide", err)
+	}
+}
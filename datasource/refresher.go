@@ -0,0 +1,103 @@
+package datasource
+
+import (
+	"context"
+	"groupie-tracker/models"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// UpdateFunc est appelée quand un rechargement périodique a trouvé des changements
+type UpdateFunc func(data *models.APIData, diff *models.DatasetDiff)
+
+// Refresher recharge périodiquement une source et publie les différences
+type Refresher struct {
+	source   DataSource
+	interval time.Duration
+	current  atomic.Pointer[models.APIData]
+
+	mu       sync.Mutex
+	onUpdate UpdateFunc
+}
+
+// NewRefresher crée un rechargement périodique à partir des données déjà chargées
+func NewRefresher(source DataSource, interval time.Duration, current *models.APIData) *Refresher {
+	r := &Refresher{source: source, interval: interval}
+	r.current.Store(current)
+	return r
+}
+
+// OnUpdate définit la fonction appelée à chaque changement détecté
+func (r *Refresher) OnUpdate(fn UpdateFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onUpdate = fn
+}
+
+// Current retourne le jeu de données le plus récent
+func (r *Refresher) Current() *models.APIData {
+	return r.current.Load()
+}
+
+// Store remplace le jeu de données de référence (après un rechargement manuel)
+func (r *Refresher) Store(data *models.APIData) {
+	r.current.Store(data)
+}
+
+// Refresh recharge la source une fois, remplace les données et retourne les différences
+func (r *Refresher) Refresh(ctx context.Context) (*models.DatasetDiff, error) {
+	data, err := r.source.Reload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := r.current.Swap(data)
+	diff := models.Diff(previous, data)
+
+	if !diff.Empty() {
+		r.mu.Lock()
+		onUpdate := r.onUpdate
+		r.mu.Unlock()
+
+		if onUpdate != nil {
+			onUpdate(data, diff)
+		}
+	}
+
+	return diff, nil
+}
+
+// Run recharge la source à intervalle régulier jusqu'à l'annulation du contexte
+func (r *Refresher) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Rien à comparer tant que le premier chargement n'est pas terminé
+			if r.Current() == nil {
+				continue
+			}
+
+			diff, err := r.Refresh(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("⚠️ Rechargement périodique impossible: %v\n", err)
+				}
+				continue
+			}
+			if !diff.Empty() {
+				log.Printf("🔄 Données mises à jour: %s\n", diff.Summary())
+			}
+		}
+	}
+}
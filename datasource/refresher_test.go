@@ -0,0 +1,125 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"groupie-tracker/models"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// dataset construit un jeu de données d'un artiste avec les concerts donnés
+func dataset(concerts map[string][]string) *models.APIData {
+	return &models.APIData{
+		Artists:   []models.Artist{{ID: 1, Name: "Queen", Members: []string{"Freddie Mercury"}}},
+		Relations: []models.Relation{{ID: 1, DatesLocations: concerts}},
+	}
+}
+
+func TestRefresherCycle(t *testing.T) {
+	initial := dataset(map[string][]string{"london-uk": {"01-01-2020"}})
+	source := NewMemory(initial)
+	refresher := NewRefresher(source, 0, initial)
+
+	var updates []*models.DatasetDiff
+	refresher.OnUpdate(func(data *models.APIData, diff *models.DatasetDiff) {
+		if data != refresher.Current() {
+			t.Errorf("OnUpdate appelé avant le remplacement des données")
+		}
+		updates = append(updates, diff)
+	})
+	ctx := context.Background()
+
+	steps := []struct {
+		name         string
+		data         *models.APIData
+		wantNew      int
+		wantCanceled int
+	}{
+		{"sans changement", initial, 0, 0},
+		{"concert ajouté", dataset(map[string][]string{"london-uk": {"01-01-2020"}, "paris-france": {"02-02-2020"}}), 1, 0},
+		{"concert annulé", dataset(map[string][]string{"paris-france": {"02-02-2020"}}), 0, 1},
+	}
+
+	calls := 0
+	for _, step := range steps {
+		source.Set(step.data)
+		diff, err := refresher.Refresh(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if len(diff.NewConcerts) != step.wantNew || len(diff.CancelledConcerts) != step.wantCanceled {
+			t.Errorf("%s: %d nouveaux et %d annulés, attendu %d et %d",
+				step.name, len(diff.NewConcerts), len(diff.CancelledConcerts), step.wantNew, step.wantCanceled)
+		}
+		if refresher.Current() != step.data {
+			t.Errorf("%s: Current() n'est pas le jeu de données rechargé", step.name)
+		}
+		if !diff.Empty() {
+			calls++
+		}
+		if len(updates) != calls {
+			t.Errorf("%s: OnUpdate appelé %d fois, attendu %d", step.name, len(updates), calls)
+		}
+	}
+}
+
+func TestRefresherKeepsDataOnError(t *testing.T) {
+	initial := dataset(nil)
+	source := NewMemory(dataset(map[string][]string{"paris-france": {"02-02-2020"}}))
+	refresher := NewRefresher(source, 0, initial)
+	refresher.OnUpdate(func(*models.APIData, *models.DatasetDiff) {
+		t.Errorf("OnUpdate appelé malgré l'échec du rechargement")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := refresher.Refresh(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Refresh() = %v, attendu %v", err, context.Canceled)
+	}
+	if refresher.Current() != initial {
+		t.Errorf("Current() remplacé malgré l'échec du rechargement")
+	}
+}
+
+func TestRefresherConcurrentRefresh(t *testing.T) {
+	versions := []*models.APIData{
+		dataset(map[string][]string{"london-uk": {"01-01-2020"}}),
+		dataset(map[string][]string{"paris-france": {"02-02-2020"}}),
+	}
+	source := NewMemory(versions[0])
+	refresher := NewRefresher(source, 0, versions[0])
+
+	var updates atomic.Int64
+	refresher.OnUpdate(func(*models.APIData, *models.DatasetDiff) { updates.Add(1) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			source.Set(versions[i%2])
+			if _, err := refresher.Refresh(context.Background()); err != nil {
+				t.Errorf("Refresh(): %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Un dernier rechargement vers des données inédites aligne le rafraîchisseur sur la source
+	final := dataset(map[string][]string{"berlin-germany": {"03-03-2020"}})
+	source.Set(final)
+	if _, err := refresher.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if refresher.Current() != final {
+		t.Errorf("Current() n'est pas le dernier jeu de données de la source")
+	}
+	if diff, _ := refresher.Refresh(context.Background()); !diff.Empty() {
+		t.Errorf("second rechargement identique: %s, attendu aucun changement", diff.Summary())
+	}
+	if updates.Load() == 0 {
+		t.Errorf("OnUpdate jamais appelé malgré les changements")
+	}
+}
@@ -0,0 +1,231 @@
+// Package fakeapi fournit un faux serveur Groupie Tracker servant un jeu de données
+// local, pour les tests (via httptest) et les démonstrations hors connexion.
+//
+//	srv := httptest.NewServer(fakeapi.New(nil, fakeapi.Options{}))
+//	client := api.NewClient(api.WithBaseURL(srv.URL + "/api"))
+package fakeapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"groupie-tracker/models"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix est le chemin sous lequel l'API est servie (comme sur groupietrackers.herokuapp.com)
+const Prefix = "/api"
+
+//go:embed fixtures/data.json
+var fixtures []byte
+
+// Fixtures retourne une copie du jeu de données embarqué
+func Fixtures() *models.APIData {
+	var data models.APIData
+	if err := json.Unmarshal(fixtures, &data); err != nil {
+		panic(fmt.Sprintf("fakeapi: fixtures invalides: %v", err))
+	}
+	return &data
+}
+
+// Options règle le comportement du faux serveur
+type Options struct {
+	Latency       time.Duration // Délai ajouté avant chaque réponse
+	ErrorRate     float64       // Probabilité (0 à 1) de répondre 503 Service Unavailable
+	MalformedRate float64       // Probabilité (0 à 1) de renvoyer un JSON tronqué
+	Missing       []string      // Endpoints qui répondent 404 (ex: "/dates")
+	Seed          int64         // Graine du tirage aléatoire (0 = selon l'heure)
+}
+
+// Server est un faux serveur Groupie Tracker (implémente http.Handler)
+type Server struct {
+	data *models.APIData
+	opts Options
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// New crée un faux serveur. Si data est nil, le jeu de données embarqué est utilisé.
+func New(data *models.APIData, opts Options) *Server {
+	if data == nil {
+		data = Fixtures()
+	}
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Server{
+		data: data,
+		opts: opts,
+		rnd:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Data retourne le jeu de données servi
+func (s *Server) Data() *models.APIData {
+	return s.data
+}
+
+// ServeHTTP route les requêtes vers /api, /artists, /locations, /dates, /relation et /{endpoint}/{id}
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "méthode non autorisée", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	endpoint, idPart, hasID := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	endpoint = "/" + endpoint
+
+	if s.opts.Latency > 0 {
+		select {
+		case <-time.After(s.opts.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if s.isMissing(endpoint) {
+		http.NotFound(w, r)
+		return
+	}
+	if s.chance(s.opts.ErrorRate) {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "service temporairement indisponible", http.StatusServiceUnavailable)
+		return
+	}
+
+	var payload interface{}
+	if !hasID {
+		payload = s.collection(r, endpoint)
+	} else {
+		id, err := strconv.Atoi(idPart)
+		if err != nil {
+			http.Error(w, "ID invalide", http.StatusBadRequest)
+			return
+		}
+		payload = s.entity(endpoint, id)
+	}
+
+	if payload == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.writeJSON(w, r, payload)
+}
+
+// collection retourne le contenu d'un endpoint de liste
+func (s *Server) collection(r *http.Request, endpoint string) interface{} {
+	switch endpoint {
+	case "/":
+		base := "http://" + r.Host + Prefix
+		return map[string]string{
+			"artists":   base + "/artists",
+			"locations": base + "/locations",
+			"dates":     base + "/dates",
+			"relation":  base + "/relation",
+		}
+	case "/artists":
+		return s.data.Artists
+	case "/locations":
+		return map[string]interface{}{"index": s.data.Locations}
+	case "/dates":
+		return map[string]interface{}{"index": s.data.Dates}
+	case "/relation":
+		return map[string]interface{}{"index": s.data.Relations}
+	}
+	return nil
+}
+
+// entity retourne une seule entité par ID (nil si absente)
+func (s *Server) entity(endpoint string, id int) interface{} {
+	switch endpoint {
+	case "/artists":
+		for _, artist := range s.data.Artists {
+			if artist.ID == id {
+				return artist
+			}
+		}
+	case "/locations":
+		for _, location := range s.data.Locations {
+			if location.ID == id {
+				return location
+			}
+		}
+	case "/dates":
+		for _, date := range s.data.Dates {
+			if date.ID == id {
+				return date
+			}
+		}
+	case "/relation":
+		for _, relation := range s.data.Relations {
+			if relation.ID == id {
+				return relation
+			}
+		}
+	}
+	return nil
+}
+
+// writeJSON encode la réponse, gère l'ETag et tronque le JSON si demandé
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, payload interface{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	if s.chance(s.opts.MalformedRate) {
+		body = body[:len(body)/2]
+	} else {
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		bytes.NewReader(body).WriteTo(w)
+	}
+}
+
+// isMissing indique si un endpoint est configuré pour répondre 404
+func (s *Server) isMissing(endpoint string) bool {
+	for _, missing := range s.opts.Missing {
+		if "/"+strings.Trim(missing, "/") == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// chance effectue un tirage aléatoire avec la probabilité donnée
+func (s *Server) chance(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.Float64() < rate
+}
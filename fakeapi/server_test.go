@@ -0,0 +1,81 @@
+package fakeapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	srv := httptest.NewServer(New(nil, Options{Missing: []string{"/dates"}}))
+	defer srv.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, Prefix, http.StatusOK},
+		{http.MethodGet, Prefix + "/artists", http.StatusOK},
+		{http.MethodGet, Prefix + "/artists/", http.StatusOK},
+		{http.MethodGet, Prefix + "/relation", http.StatusOK},
+		{http.MethodGet, Prefix + "/artists/1", http.StatusOK},
+		{http.MethodHead, Prefix + "/locations/1", http.StatusOK},
+		{http.MethodGet, Prefix + "/artists/9999", http.StatusNotFound},
+		{http.MethodGet, Prefix + "/artists/abc", http.StatusBadRequest},
+		{http.MethodGet, Prefix + "/dates", http.StatusNotFound},
+		{http.MethodGet, Prefix + "/inconnu", http.StatusNotFound},
+		{http.MethodPost, Prefix + "/artists", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("%s %s = %d, attendu %d", tt.method, tt.path, resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerETag(t *testing.T) {
+	srv := httptest.NewServer(New(nil, Options{}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + Prefix + "/artists")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("réponse sans ETag")
+	}
+
+	for _, tt := range []struct {
+		etag string
+		want int
+	}{
+		{etag, http.StatusNotModified},
+		{`"perime"`, http.StatusOK},
+	} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+Prefix+"/artists", nil)
+		req.Header.Set("If-None-Match", tt.etag)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("If-None-Match %s = %d, attendu %d", tt.etag, resp.StatusCode, tt.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package fuzzy compare des textes en tolérant les fautes de frappe (distance d'édition).
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tolerance fixe le nombre de fautes admises selon la longueur de la recherche :
+// aucune en dessous de MinLength caractères, une à partir de MinLength,
+// puis une de plus tous les PerRunes caractères, sans dépasser Max.
+type Tolerance struct {
+	MinLength int
+	PerRunes  int
+	Max       int // 0 désactive la tolérance
+}
+
+// DefaultTolerance admet une faute dès 4 caractères ("quen"), deux dès 8 ("pink floid")
+var DefaultTolerance = Tolerance{MinLength: 4, PerRunes: 4, Max: 3}
+
+// Allowed retourne le nombre de fautes admises pour une recherche
+func (t Tolerance) Allowed(query string) int {
+	n := utf8.RuneCountInString(query)
+	if t.Max <= 0 || n < t.MinLength {
+		return 0
+	}
+	allowed := 1
+	if t.PerRunes > 0 {
+		allowed += (n - t.MinLength) / t.PerRunes
+	}
+	return min(allowed, t.Max)
+}
+
+// Distance calcule la distance de Levenshtein entre deux chaînes (en caractères)
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// prefixMinLength est la longueur minimale d'une recherche comparée au début des passages :
+// en dessous, trop de mots courts se ressemblent ("quen" et "buenos")
+const prefixMinLength = 5
+
+// Result est le passage d'un texte le plus proche d'une recherche
+type Result struct {
+	Distance int // nombre de fautes
+	Start    int // plage d'octets [Start, End) du passage dans le texte
+	End      int
+}
+
+// Match cherche dans text le passage le plus proche de query, sans tenir compte de la casse.
+// Les passages comparés sont des suites de mots du même nombre de mots que la recherche ;
+// un passage plus long est aussi comparé à son début, pour les recherches en cours de frappe.
+func Match(text, query string, tol Tolerance) (Result, bool) {
+	queryWords := strings.Fields(strings.ToLower(query))
+	if len(queryWords) == 0 {
+		return Result{}, false
+	}
+	normalized := strings.Join(queryWords, " ")
+	allowed := tol.Allowed(normalized)
+	if allowed == 0 {
+		return Result{}, false
+	}
+
+	words := wordSpans(text)
+	best, found := Result{Distance: allowed + 1}, false
+	for i := 0; i+len(queryWords) <= len(words); i++ {
+		start, end := words[i][0], words[i+len(queryWords)-1][1]
+		window := strings.Join(strings.Fields(strings.ToLower(text[start:end])), " ")
+
+		d := Distance(normalized, window)
+		if n := utf8.RuneCountInString(normalized); n >= prefixMinLength {
+			if prefix := truncate(window, n); prefix != window {
+				d = min(d, Distance(normalized, prefix))
+			}
+		}
+		if d < best.Distance {
+			best, found = Result{Distance: d, Start: start, End: end}, true
+		}
+	}
+	return best, found
+}
+
+// wordSpans retourne les plages d'octets des mots de text (séparés par des espaces)
+func wordSpans(text string) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range text {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	return spans
+}
+
+// truncate garde les n premiers caractères d'une chaîne
+func truncate(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
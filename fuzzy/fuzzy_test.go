@@ -0,0 +1,99 @@
+package fuzzy
+
+import "testing"
+
+func TestToleranceAllowed(t *testing.T) {
+	tests := []struct {
+		query string
+		tol   Tolerance
+		want  int
+	}{
+		{"que", DefaultTolerance, 0},
+		{"quen", DefaultTolerance, 1},
+		{"pink flo", DefaultTolerance, 2},
+		{"pink floid", DefaultTolerance, 2},
+		{"freddie mercurie", DefaultTolerance, 3},
+		{"une recherche vraiment très longue", DefaultTolerance, 3},
+		{"pink floid", Tolerance{MinLength: 4, PerRunes: 4, Max: 1}, 1},
+		{"pink floid", Tolerance{}, 0},
+		{"éèàç", DefaultTolerance, 1}, // longueur en caractères, pas en octets
+	}
+
+	for _, tt := range tests {
+		if got := tt.tol.Allowed(tt.query); got != tt.want {
+			t.Errorf("%+v.Allowed(%q) = %d, attendu %d", tt.tol, tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"queen", "queen", 0},
+		{"quen", "queen", 1},
+		{"pink floid", "pink floyd", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"café", "cafe", 1}, // une substitution, pas deux octets
+	}
+
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, attendu %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := Distance(tt.b, tt.a); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, attendu %d (symétrie)", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		query    string
+		want     bool
+		distance int
+		passage  string // texte[Start:End] attendu
+	}{
+		{"quen", "Queen", "quen", true, 1, "Queen"},
+		{"pink floid", "Pink Floyd", "pink floid", true, 1, "Pink Floyd"},
+		{"en cours de frappe", "Pink Floyd", "pinc flo", true, 1, "Pink Floyd"},
+		{"second mot", "Freddie Mercury", "mercuri", true, 1, "Mercury"},
+		{"deux mots", "Freddie Mercury", "freddie mercuri", true, 1, "Freddie Mercury"},
+		{"casse ignorée", "PINK FLOYD", "pink floid", true, 1, "PINK FLOYD"},
+		{"espaces multiples", "Pink   Floyd", "pink  floid", true, 1, "Pink   Floyd"},
+		{"trop court", "Queen", "qen", false, 0, ""},
+		{"mots courts sans préfixe", "Buenos Aires", "quen", false, 0, ""},
+		{"trop de fautes", "Queen", "kwin", false, 0, ""},
+		{"plus de mots que le texte", "Queen", "queen live", false, 0, ""},
+		{"recherche vide", "Queen", "  ", false, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Match(tt.text, tt.query, DefaultTolerance)
+			if ok != tt.want {
+				t.Fatalf("Match(%q, %q) trouvé = %v, attendu %v", tt.text, tt.query, ok, tt.want)
+			}
+			if !ok {
+				return
+			}
+			if got.Distance != tt.distance {
+				t.Errorf("Match(%q, %q) distance = %d, attendu %d", tt.text, tt.query, got.Distance, tt.distance)
+			}
+			if passage := tt.text[got.Start:got.End]; passage != tt.passage {
+				t.Errorf("Match(%q, %q) passage = %q, attendu %q", tt.text, tt.query, passage, tt.passage)
+			}
+		})
+	}
+}
+
+func TestMatchDisabled(t *testing.T) {
+	if _, ok := Match("Queen", "quen", Tolerance{}); ok {
+		t.Errorf("Match() avec une tolérance nulle ne doit rien trouver")
+	}
+}
@@ -0,0 +1,306 @@
+package geo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"groupie-tracker/fuzzy"
+	"groupie-tracker/models"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed data/gazetteer.json
+var gazetteerJSON []byte
+
+// EarthRadiusKm est le rayon moyen de la Terre utilisé pour les distances
+const EarthRadiusKm = 6371.0
+
+// Coordinates est une position géographique en degrés décimaux
+type Coordinates struct {
+	Lat float64
+	Lon float64
+}
+
+// String retourne les coordonnées au format "48.8600, 2.3500"
+func (c Coordinates) String() string {
+	return fmt.Sprintf("%.4f, %.4f", c.Lat, c.Lon)
+}
+
+// Distance retourne la distance orthodromique en kilomètres entre deux positions (formule de haversine)
+func Distance(a, b Coordinates) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Precision indique le niveau de détail d'une position trouvée
+type Precision int
+
+const (
+	PrecisionUnknown Precision = iota // Lieu non localisé
+	PrecisionCountry                  // Centre du pays
+	PrecisionRegion                   // Centre de la région ou de l'État
+	PrecisionCity                     // Ville
+)
+
+// String retourne le libellé de la précision
+func (p Precision) String() string {
+	switch p {
+	case PrecisionCity:
+		return "ville"
+	case PrecisionRegion:
+		return "région"
+	case PrecisionCountry:
+		return "pays"
+	default:
+		return "inconnue"
+	}
+}
+
+// Location est le résultat du géocodage d'un lieu
+type Location struct {
+	Place models.Place
+	Coordinates
+	Precision Precision
+	Fuzzy     bool // Nom retrouvé de façon approchée (faute de frappe)
+}
+
+// Approximate indique si la position n'est pas celle de la ville elle-même
+func (l Location) Approximate() bool {
+	return l.Fuzzy || l.Precision < PrecisionCity
+}
+
+// gazetteerEntry est une entrée du dictionnaire géographique embarqué
+type gazetteerEntry struct {
+	Name    string  `json:"name"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+}
+
+var (
+	gazetteerOnce sync.Once
+	cities        map[string]map[string]Coordinates // code pays -> ville -> position
+	regionCenters map[string]map[string]Coordinates // code pays -> région -> position
+)
+
+// loadGazetteer lit le dictionnaire géographique embarqué au premier usage
+func loadGazetteer() {
+	gazetteerOnce.Do(func() {
+		var table struct {
+			Cities  []gazetteerEntry `json:"cities"`
+			Regions []gazetteerEntry `json:"regions"`
+		}
+		if err := json.Unmarshal(gazetteerJSON, &table); err != nil {
+			panic("geo: dictionnaire géographique invalide: " + err.Error())
+		}
+
+		cities = indexEntries(table.Cities)
+		regionCenters = indexEntries(table.Regions)
+	})
+}
+
+// isCity indique si un nom normalisé est une ville connue du pays
+func isCity(code, name string) bool {
+	loadGazetteer()
+	_, ok := cities[code][name]
+	return ok
+}
+
+// indexEntries regroupe les entrées par pays puis par nom normalisé
+func indexEntries(entries []gazetteerEntry) map[string]map[string]Coordinates {
+	index := make(map[string]map[string]Coordinates)
+	for _, e := range entries {
+		if index[e.Country] == nil {
+			index[e.Country] = make(map[string]Coordinates)
+		}
+		index[e.Country][normalize(e.Name)] = Coordinates{Lat: e.Lat, Lon: e.Lon}
+	}
+	return index
+}
+
+// Geocoder localise les lieux des concerts à partir des tables embarquées (sans accès réseau).
+// Il mémorise les lieux non localisés ou approximatifs pour en faire le rapport.
+type Geocoder struct {
+	mu          sync.Mutex
+	cache       map[string]Location
+	unresolved  map[string]bool
+	approximate map[string]bool
+}
+
+// NewGeocoder crée un nouveau service de géocodage
+func NewGeocoder() *Geocoder {
+	loadTables()
+	loadGazetteer()
+	return &Geocoder{
+		cache:       make(map[string]Location),
+		unresolved:  make(map[string]bool),
+		approximate: make(map[string]bool),
+	}
+}
+
+// Geocode localise une clé de lieu de l'API ("los_angeles-usa")
+func (g *Geocoder) Geocode(key string) (Location, bool) {
+	return g.GeocodePlace(ParsePlace(key))
+}
+
+// GeocodePlace localise un lieu déjà analysé, dans l'ordre :
+//   - la ville (nom exact puis approché) dans son pays ;
+//   - la région ou l'État ;
+//   - le centre du pays.
+func (g *Geocoder) GeocodePlace(place models.Place) (Location, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if loc, ok := g.cache[place.Key]; ok {
+		return loc, loc.Precision != PrecisionUnknown
+	}
+
+	loc := resolve(place)
+	g.cache[place.Key] = loc
+	switch {
+	case loc.Precision == PrecisionUnknown:
+		g.unresolved[place.Key] = true
+	case loc.Approximate():
+		g.approximate[place.Key] = true
+	}
+	return loc, loc.Precision != PrecisionUnknown
+}
+
+// resolve cherche la position la plus précise d'un lieu
+func resolve(place models.Place) Location {
+	loc := Location{Place: place}
+	code := place.CountryCode
+
+	if place.City != "" {
+		if coords, fuzzy, ok := lookup(cities[code], place.City); ok {
+			loc.Coordinates, loc.Precision, loc.Fuzzy = coords, PrecisionCity, fuzzy
+			return loc
+		}
+	}
+	if place.Region != "" {
+		if coords, fuzzy, ok := lookup(regionCenters[code], place.Region); ok {
+			loc.Coordinates, loc.Precision, loc.Fuzzy = coords, PrecisionRegion, fuzzy
+			return loc
+		}
+	}
+	if c, ok := byCode[code]; ok {
+		loc.Coordinates, loc.Precision = Coordinates{Lat: c.Lat, Lon: c.Lon}, PrecisionCountry
+	}
+	return loc
+}
+
+// placeTolerance admet une faute de frappe tous les 5 caractères,
+// pour éviter les confusions entre noms courts
+var placeTolerance = fuzzy.Tolerance{MinLength: 5, PerRunes: 5, Max: 3}
+
+// lookup cherche un nom dans une table, exactement puis à une faute de frappe près
+func lookup(table map[string]Coordinates, name string) (Coordinates, bool, bool) {
+	key := normalize(name)
+	if coords, ok := table[key]; ok {
+		return coords, false, true
+	}
+
+	tolerance := placeTolerance.Allowed(key)
+	if tolerance == 0 {
+		return Coordinates{}, false, false
+	}
+
+	best, bestDistance := "", tolerance+1
+	for candidate := range table {
+		d := fuzzy.Distance(key, candidate)
+		if d < bestDistance || (d == bestDistance && candidate < best) {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == "" {
+		return Coordinates{}, false, false
+	}
+	return table[best], true, true
+}
+
+// Report résume les lieux qui n'ont pas pu être localisés à la ville près
+type Report struct {
+	Resolved    int      // Lieux localisés
+	Approximate []string // Clés localisées de façon approchée (région, pays ou nom approché)
+	Unresolved  []string // Clés sans aucune position
+}
+
+// Summary retourne un résumé court ("12 lieu(x) localisé(s), 2 approximatif(s), 1 inconnu(s)")
+func (r Report) Summary() string {
+	return fmt.Sprintf("%d lieu(x) localisé(s), %d approximatif(s), %d inconnu(s)",
+		r.Resolved, len(r.Approximate), len(r.Unresolved))
+}
+
+// Report retourne le rapport des lieux géocodés jusqu'ici
+func (g *Geocoder) Report() Report {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	report := Report{
+		Resolved:    len(g.cache) - len(g.unresolved),
+		Approximate: sortedKeys(g.approximate),
+		Unresolved:  sortedKeys(g.unresolved),
+	}
+	return report
+}
+
+// Resolve géocode toutes les clés de lieu des relations et retourne leur rapport.
+// Seules ces clés sont comptées, même si le géocodeur en a déjà localisé d'autres
+// lors d'un chargement précédent.
+func (g *Geocoder) Resolve(relations []models.Relation) Report {
+	var report Report
+	seen := make(map[string]bool)
+	for _, relation := range relations {
+		for key := range relation.DatesLocations {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			loc, ok := g.Geocode(key)
+			switch {
+			case !ok:
+				report.Unresolved = append(report.Unresolved, key)
+			case loc.Approximate():
+				report.Resolved++
+				report.Approximate = append(report.Approximate, key)
+			default:
+				report.Resolved++
+			}
+		}
+	}
+	sort.Strings(report.Approximate)
+	sort.Strings(report.Unresolved)
+	return report
+}
+
+// sortedKeys retourne les clés d'un ensemble triées
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String retourne le rapport détaillé, une clé par ligne
+func (r Report) String() string {
+	var b strings.Builder
+	b.WriteString(r.Summary())
+	for _, key := range r.Approximate {
+		fmt.Fprintf(&b, "\n  ~ %s", key)
+	}
+	for _, key := range r.Unresolved {
+		fmt.Fprintf(&b, "\n  ? %s", key)
+	}
+	return b.String()
+}
@@ -0,0 +1,150 @@
+// Package geo analyse les lieux de concerts de l'API (ville, région, pays)
+// à l'aide de tables embarquées.
+package geo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"groupie-tracker/models"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed data/countries.json
+var countriesJSON []byte
+
+// country est une entrée de la table des pays
+type country struct {
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+	Lat     float64  `json:"lat"` // Centre approximatif du pays
+	Lon     float64  `json:"lon"`
+}
+
+var (
+	tablesOnce sync.Once
+	byAlias    map[string]country
+	byCode     map[string]country
+	regions    map[string]map[string]bool
+)
+
+// loadTables lit les tables embarquées au premier usage
+func loadTables() {
+	tablesOnce.Do(func() {
+		var tables struct {
+			Countries []country           `json:"countries"`
+			Regions   map[string][]string `json:"regions"`
+		}
+		if err := json.Unmarshal(countriesJSON, &tables); err != nil {
+			panic("geo: table des pays invalide: " + err.Error())
+		}
+
+		byAlias = make(map[string]country)
+		byCode = make(map[string]country)
+		for _, c := range tables.Countries {
+			byCode[c.Code] = c
+			byAlias[strings.ToLower(c.Code)] = c
+			byAlias[normalize(c.Name)] = c
+			for _, alias := range c.Aliases {
+				byAlias[normalize(alias)] = c
+			}
+		}
+
+		regions = make(map[string]map[string]bool)
+		for code, names := range tables.Regions {
+			regions[code] = make(map[string]bool, len(names))
+			for _, name := range names {
+				regions[code][normalize(name)] = true
+			}
+		}
+	})
+}
+
+// normalize convertit un nom ou une clé en forme comparable ("New Zealand" -> "new_zealand")
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '_'
+	}), "_")
+}
+
+// LookupCountry retourne le code ISO et le nom d'un pays à partir d'un nom, d'un alias ou d'un code
+func LookupCountry(nameOrAlias string) (code, name string, ok bool) {
+	loadTables()
+	c, ok := byAlias[normalize(nameOrAlias)]
+	return c.Code, c.Name, ok
+}
+
+// CountryName retourne le nom d'un pays à partir de son code ISO
+func CountryName(code string) (string, bool) {
+	loadTables()
+	c, ok := byCode[strings.ToUpper(code)]
+	return c.Name, ok
+}
+
+// ParsePlace analyse une clé de lieu de l'API :
+//   - "ville-pays" ou "région-pays" (la région est reconnue grâce à la table embarquée,
+//     une ville du même nom étant prioritaire) ;
+//   - "ville-région-pays".
+func ParsePlace(key string) models.Place {
+	loadTables()
+
+	place := models.Place{Key: key}
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(key)), "-")
+	if len(parts) == 0 || parts[0] == "" {
+		return place
+	}
+
+	last := parts[len(parts)-1]
+	if c, ok := byAlias[normalize(last)]; ok {
+		place.Country = c.Name
+		place.CountryCode = c.Code
+	} else {
+		place.Country = titleCase(last)
+	}
+
+	switch len(parts) {
+	case 1:
+		if place.CountryCode == "" {
+			// Un seul élément inconnu : c'est plus probablement une ville
+			place.City, place.Country = place.Country, ""
+		}
+	case 2:
+		// Un nom à la fois ville et État ("washington-usa") désigne la ville
+		name := normalize(parts[0])
+		if regions[place.CountryCode][name] && !isCity(place.CountryCode, name) {
+			place.Region = titleCase(parts[0])
+		} else {
+			place.City = titleCase(parts[0])
+		}
+	default:
+		place.City = titleCase(parts[0])
+		place.Region = titleCase(strings.Join(parts[1:len(parts)-1], "_"))
+	}
+
+	return place
+}
+
+// Mots laissés en minuscules au milieu d'un nom ("Rio de Janeiro", "Playa del Carmen")
+var lowerWords = map[string]bool{
+	"de": true, "del": true, "da": true, "do": true, "dos": true, "das": true,
+	"la": true, "le": true, "les": true, "of": true, "and": true, "sur": true,
+}
+
+// titleCase met en forme un élément de clé ("playa_del_carmen" -> "Playa del Carmen")
+func titleCase(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_' || r == ' '
+	})
+	for i, word := range words {
+		if i > 0 && lowerWords[word] {
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToUpper(r)) + word[size:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,99 @@
+package geo
+
+import (
+	"groupie-tracker/models"
+	"reflect"
+	"testing"
+)
+
+func TestParsePlace(t *testing.T) {
+	tests := []struct {
+		key  string
+		want models.Place
+	}{
+		{"los_angeles-usa", models.Place{City: "Los Angeles", Country: "États-Unis", CountryCode: "US"}},
+		{"north_carolina-usa", models.Place{Region: "North Carolina", Country: "États-Unis", CountryCode: "US"}},
+		{"washington-usa", models.Place{City: "Washington", Country: "États-Unis", CountryCode: "US"}},
+		{"seattle-washington-usa", models.Place{City: "Seattle", Region: "Washington", Country: "États-Unis", CountryCode: "US"}},
+		{"playa_del_carmen-mexico", models.Place{City: "Playa del Carmen", Country: "Mexique", CountryCode: "MX"}},
+		{"paris-france", models.Place{City: "Paris", Country: "France", CountryCode: "FR"}},
+		{"Paris-FRANCE", models.Place{City: "Paris", Country: "France", CountryCode: "FR"}},
+		{"atlantis", models.Place{City: "Atlantis"}},
+		{"gotham-nowhere", models.Place{City: "Gotham", Country: "Nowhere"}},
+		{"", models.Place{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			tt.want.Key = tt.key
+			if got := ParsePlace(tt.key); got != tt.want {
+				t.Errorf("ParsePlace(%q) = %+v, attendu %+v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeocodePrecision(t *testing.T) {
+	geocoder := NewGeocoder()
+
+	tests := []struct {
+		key       string
+		want      Precision
+		wantFuzzy bool
+		near      Coordinates // position attendue à 50 km près
+	}{
+		{"washington-usa", PrecisionCity, false, Coordinates{Lat: 38.91, Lon: -77.04}},
+		{"new_york-usa", PrecisionCity, false, Coordinates{Lat: 40.71, Lon: -74.01}},
+		{"georgia-usa", PrecisionRegion, false, Coordinates{Lat: 32.70, Lon: -83.40}},
+		{"pariss-france", PrecisionCity, true, Coordinates{Lat: 48.86, Lon: 2.35}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			loc, ok := geocoder.Geocode(tt.key)
+			if !ok {
+				t.Fatalf("Geocode(%q) non localisé", tt.key)
+			}
+			if loc.Precision != tt.want || loc.Fuzzy != tt.wantFuzzy {
+				t.Errorf("Geocode(%q) = précision %v (approché %v), attendu %v (approché %v)",
+					tt.key, loc.Precision, loc.Fuzzy, tt.want, tt.wantFuzzy)
+			}
+			if d := Distance(loc.Coordinates, tt.near); d > 50 {
+				t.Errorf("Geocode(%q) = %v, à %.0f km de %v", tt.key, loc.Coordinates, d, tt.near)
+			}
+		})
+	}
+}
+
+func TestResolveReportsOnlyGivenRelations(t *testing.T) {
+	geocoder := NewGeocoder()
+	relation := func(keys ...string) []models.Relation {
+		concerts := make(map[string][]string)
+		for _, key := range keys {
+			concerts[key] = []string{"01-01-2020"}
+		}
+		return []models.Relation{{ID: 1, DatesLocations: concerts}, {ID: 2, DatesLocations: concerts}}
+	}
+
+	steps := []struct {
+		name        string
+		relations   []models.Relation
+		resolved    int
+		approximate []string
+		unresolved  []string
+	}{
+		{"premier chargement", relation("paris-france", "georgia-usa", "nulle_part-atlantide"), 2, []string{"georgia-usa"}, []string{"nulle_part-atlantide"}},
+		{"rechargement", relation("new_york-usa"), 1, nil, nil},
+		{"clés déjà géocodées", relation("georgia-usa"), 1, []string{"georgia-usa"}, nil},
+	}
+
+	for _, step := range steps {
+		report := geocoder.Resolve(step.relations)
+		if report.Resolved != step.resolved ||
+			!reflect.DeepEqual(report.Approximate, step.approximate) ||
+			!reflect.DeepEqual(report.Unresolved, step.unresolved) {
+			t.Errorf("%s: Resolve() = %+v, attendu %d localisé(s), %v approximatif(s), %v inconnu(s)",
+				step.name, report, step.resolved, step.approximate, step.unresolved)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package images
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"groupie-tracker/internal/fsutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// Suffixes des fichiers du cache
+const (
+	originalSuffix = ".orig"
+	thumbSuffix    = ".thumb.png"
+)
+
+// diskCache conserve originaux et miniatures, les moins récemment utilisés
+// étant supprimés quand la taille totale dépasse maxSize
+type diskCache struct {
+	dir     string
+	maxSize int64
+	mu      sync.Mutex
+}
+
+// path retourne le chemin du fichier associé à une URL
+func (d *diskCache) path(url, suffix string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:16])+suffix)
+}
+
+// get lit un fichier du cache et le marque comme récemment utilisé
+func (d *diskCache) get(url, suffix string) ([]byte, bool) {
+	if d.dir == "" {
+		return nil, false
+	}
+
+	path := d.path(url, suffix)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return data, true
+}
+
+// put enregistre un fichier puis réduit le cache si nécessaire
+func (d *diskCache) put(url, suffix string, data []byte) {
+	if d.dir == "" {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		log.Printf("⚠️ Cache d'images indisponible: %v\n", err)
+		return
+	}
+	if err := fsutil.WriteFileAtomic(d.path(url, suffix), data); err != nil {
+		log.Printf("⚠️ Écriture dans le cache d'images impossible: %v\n", err)
+		return
+	}
+
+	d.prune()
+}
+
+// prune supprime les fichiers les moins récemment utilisés au-delà de maxSize
+func (d *diskCache) prune() {
+	if d.maxSize <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+
+	type cachedFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var files []cachedFile
+	var total int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cachedFile{
+			path:    filepath.Join(d.dir, entry.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+		total += info.Size()
+	}
+
+	if total <= d.maxSize {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	for _, file := range files {
+		if total <= d.maxSize {
+			break
+		}
+		if err := os.Remove(file.path); err == nil {
+			total -= file.size
+		}
+	}
+}
+
+// memoryCache garde en mémoire les max miniatures les plus récemment utilisées ;
+// il n'est pas protégé, le Service le verrouille
+type memoryCache struct {
+	max   int
+	order *list.List // la plus récente en tête
+	items map[string]*list.Element
+}
+
+// memoryEntry est une miniature en mémoire
+type memoryEntry struct {
+	key string
+	res fyne.Resource
+}
+
+// newMemoryCache crée un cache mémoire de n miniatures
+func newMemoryCache(n int) *memoryCache {
+	return &memoryCache{
+		max:   n,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get retourne une miniature et la marque comme récemment utilisée
+func (m *memoryCache) get(key string) (fyne.Resource, bool) {
+	elem, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memoryEntry).res, true
+}
+
+// put ajoute une miniature et oublie les moins récemment utilisées au-delà de max
+func (m *memoryCache) put(key string, res fyne.Resource) {
+	if elem, ok := m.items[key]; ok {
+		elem.Value.(*memoryEntry).res = res
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	m.items[key] = m.order.PushFront(&memoryEntry{key: key, res: res})
+	for m.order.Len() > m.max {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryEntry).key)
+	}
+}
@@ -0,0 +1,80 @@
+package images
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestDiskCachePrunesLeastRecentlyUsed(t *testing.T) {
+	cache := &diskCache{dir: t.TempDir(), maxSize: 250}
+	data := bytes.Repeat([]byte("x"), 100)
+
+	// a puis b, a étant ensuite relu : b devient le moins récemment utilisé
+	cache.put("a", originalSuffix, data)
+	cache.put("b", originalSuffix, data)
+	past := time.Now().Add(-time.Hour)
+	os.Chtimes(cache.path("a", originalSuffix), past, past)
+	os.Chtimes(cache.path("b", originalSuffix), past.Add(time.Minute), past.Add(time.Minute))
+	if _, ok := cache.get("a", originalSuffix); !ok {
+		t.Fatalf("get(a) absent du cache")
+	}
+
+	cache.put("c", originalSuffix, data)
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"a", true},
+		{"b", false},
+		{"c", true},
+	}
+	for _, tt := range tests {
+		if _, ok := cache.get(tt.url, originalSuffix); ok != tt.want {
+			t.Errorf("get(%q) présent = %v, attendu %v", tt.url, ok, tt.want)
+		}
+	}
+}
+
+func TestDiskCacheUnlimited(t *testing.T) {
+	cache := &diskCache{dir: t.TempDir()}
+	for _, url := range []string{"a", "b", "c"} {
+		cache.put(url, thumbSuffix, bytes.Repeat([]byte("x"), 1<<10))
+	}
+	for _, url := range []string{"a", "b", "c"} {
+		if _, ok := cache.get(url, thumbSuffix); !ok {
+			t.Errorf("get(%q) absent d'un cache sans limite", url)
+		}
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newMemoryCache(2)
+	resource := func(name string) fyne.Resource { return fyne.NewStaticResource(name, nil) }
+
+	cache.put("a", resource("a"))
+	cache.put("b", resource("b"))
+	cache.get("a")
+	cache.put("c", resource("c"))
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"a", true},
+		{"b", false},
+		{"c", true},
+	}
+	for _, tt := range tests {
+		if _, ok := cache.get(tt.key); ok != tt.want {
+			t.Errorf("get(%q) présent = %v, attendu %v", tt.key, ok, tt.want)
+		}
+	}
+	if len(cache.items) != 2 || cache.order.Len() != 2 {
+		t.Errorf("%d miniatures en mémoire, attendu 2", len(cache.items))
+	}
+}
@@ -0,0 +1,317 @@
+// Package images télécharge les images des artistes, les met en cache sur disque
+// avec leurs miniatures et les fournit sous forme de ressources fyne.
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+const (
+	// DefaultWorkers est le nombre de téléchargements simultanés
+	DefaultWorkers = 4
+	// DefaultMaxCacheSize est la taille maximale du cache disque (64 Mo)
+	DefaultMaxCacheSize int64 = 64 << 20
+	// DefaultThumbnailSize est la taille (en pixels) du plus grand côté d'une miniature
+	DefaultThumbnailSize = 160
+	// DefaultTimeout est la durée maximale d'un téléchargement
+	DefaultTimeout = 30 * time.Second
+	// DefaultMemoryThumbnails est le nombre de miniatures gardées en mémoire
+	DefaultMemoryThumbnails = 256
+
+	// maxImageSize limite la taille d'une image téléchargée
+	maxImageSize = 16 << 20
+)
+
+// ErrNoURL est retournée pour un artiste sans image
+var ErrNoURL = errors.New("aucune URL d'image")
+
+// Callback reçoit la ressource demandée, depuis un goroutine de travail
+type Callback = func(fyne.Resource, error)
+
+// Option configure un Service
+type Option func(*Service)
+
+// WithWorkers définit le nombre de téléchargements simultanés
+func WithWorkers(n int) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.workers = n
+		}
+	}
+}
+
+// WithMaxCacheSize définit la taille maximale du cache disque (0 = illimitée)
+func WithMaxCacheSize(size int64) Option {
+	return func(s *Service) {
+		s.cache.maxSize = size
+	}
+}
+
+// WithThumbnailSize définit la taille du plus grand côté des miniatures
+func WithThumbnailSize(px int) Option {
+	return func(s *Service) {
+		if px > 0 {
+			s.thumbSize = px
+		}
+	}
+}
+
+// WithMemoryThumbnails définit le nombre de miniatures gardées en mémoire
+func WithMemoryThumbnails(n int) Option {
+	return func(s *Service) {
+		if n > 0 {
+			s.thumbs = newMemoryCache(n)
+		}
+	}
+}
+
+// WithHTTPClient utilise un client HTTP personnalisé pour les téléchargements
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Service) {
+		if client != nil {
+			s.client = client
+		}
+	}
+}
+
+// DefaultCacheDir retourne le dossier de cache des images de l'utilisateur
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "groupie-tracker", "images"), nil
+}
+
+// request est une demande d'image en attente de traitement
+type request struct {
+	url       string
+	thumbnail bool
+}
+
+// key identifie une demande (miniature ou original) pour le regroupement
+func (r request) key() string {
+	if r.thumbnail {
+		return "thumb:" + r.url
+	}
+	return "orig:" + r.url
+}
+
+// Service télécharge les images avec un nombre limité de goroutines
+type Service struct {
+	client    *http.Client
+	cache     *diskCache
+	workers   int
+	thumbSize int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   chan request
+	wg     sync.WaitGroup
+
+	// Miniatures récentes déjà prêtes et demandes en cours (regroupées par URL)
+	mu      sync.Mutex
+	thumbs  *memoryCache
+	waiting map[string][]Callback
+}
+
+// NewService crée un service d'images avec un cache disque dans dir (vide = pas de cache disque)
+func NewService(dir string, opts ...Option) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Service{
+		client:    &http.Client{Timeout: DefaultTimeout},
+		cache:     &diskCache{dir: dir, maxSize: DefaultMaxCacheSize},
+		workers:   DefaultWorkers,
+		thumbSize: DefaultThumbnailSize,
+		ctx:       ctx,
+		cancel:    cancel,
+		jobs:      make(chan request),
+		thumbs:    newMemoryCache(DefaultMemoryThumbnails),
+		waiting:   make(map[string][]Callback),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.work()
+	}
+
+	return s
+}
+
+// Close arrête les téléchargements en cours et les goroutines de travail
+func (s *Service) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Thumbnail demande la miniature d'une image. done est appelée immédiatement
+// si la miniature est en mémoire, sinon depuis un goroutine de travail.
+func (s *Service) Thumbnail(url string, done Callback) {
+	s.submit(request{url: url, thumbnail: true}, done)
+}
+
+// Original demande l'image en taille réelle
+func (s *Service) Original(url string, done Callback) {
+	s.submit(request{url: url}, done)
+}
+
+// submit enregistre une demande et la confie aux goroutines de travail
+func (s *Service) submit(req request, done Callback) {
+	if req.url == "" {
+		done(nil, ErrNoURL)
+		return
+	}
+
+	key := req.key()
+
+	s.mu.Lock()
+	if res, ok := s.thumbs.get(key); ok {
+		s.mu.Unlock()
+		done(res, nil)
+		return
+	}
+	first := len(s.waiting[key]) == 0
+	s.waiting[key] = append(s.waiting[key], done)
+	s.mu.Unlock()
+
+	// Une seule file par URL : les demandes suivantes attendent le même résultat
+	if first {
+		go func() {
+			select {
+			case s.jobs <- req:
+			case <-s.ctx.Done():
+				s.deliver(req, nil, s.ctx.Err())
+			}
+		}()
+	}
+}
+
+// work traite les demandes jusqu'à la fermeture du service
+func (s *Service) work() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case req := <-s.jobs:
+			var res fyne.Resource
+			var err error
+			if req.thumbnail {
+				res, err = s.loadThumbnail(req.url)
+			} else {
+				res, err = s.loadOriginal(req.url)
+			}
+			s.deliver(req, res, err)
+		}
+	}
+}
+
+// deliver transmet le résultat à toutes les demandes en attente pour la même image
+func (s *Service) deliver(req request, res fyne.Resource, err error) {
+	key := req.key()
+
+	s.mu.Lock()
+	callbacks := s.waiting[key]
+	delete(s.waiting, key)
+	if err == nil && req.thumbnail {
+		s.thumbs.put(key, res)
+	}
+	s.mu.Unlock()
+
+	for _, done := range callbacks {
+		done(res, err)
+	}
+}
+
+// loadOriginal retourne l'image originale depuis le cache ou le réseau
+func (s *Service) loadOriginal(url string) (fyne.Resource, error) {
+	data, err := s.original(url)
+	if err != nil {
+		return nil, err
+	}
+	return fyne.NewStaticResource(filepath.Base(url), data), nil
+}
+
+// loadThumbnail retourne la miniature depuis le cache ou la calcule
+func (s *Service) loadThumbnail(url string) (fyne.Resource, error) {
+	name := filepath.Base(url) + ".thumb.png"
+
+	if data, ok := s.cache.get(url, thumbSuffix); ok {
+		return fyne.NewStaticResource(name, data), nil
+	}
+
+	original, err := s.original(url)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := thumbnail(original, s.thumbSize)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de la miniature de %s: %w", url, err)
+	}
+	s.cache.put(url, thumbSuffix, data)
+
+	return fyne.NewStaticResource(name, data), nil
+}
+
+// original retourne le contenu de l'image originale
+func (s *Service) original(url string) ([]byte, error) {
+	if data, ok := s.cache.get(url, originalSuffix); ok {
+		return data, nil
+	}
+
+	data, err := s.download(url)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.put(url, originalSuffix, data)
+	return data, nil
+}
+
+// download télécharge une image et vérifie qu'elle est décodable
+func (s *Service) download(url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de la requête %s: %w", url, err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors du téléchargement de %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("erreur lors du téléchargement de %s: statut %d", url, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la lecture de %s: %w", url, err)
+	}
+	if len(data) > maxImageSize {
+		return nil, fmt.Errorf("image %s trop volumineuse (plus de %d octets)", url, maxImageSize)
+	}
+
+	if err := checkImage(data); err != nil {
+		return nil, fmt.Errorf("image %s illisible: %w", url, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,115 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// result est le résultat reçu par un Callback
+type result struct {
+	res fyne.Resource
+	err error
+}
+
+// waitResult attend le résultat d'une demande
+func waitResult(t *testing.T, results <-chan result) result {
+	t.Helper()
+	select {
+	case r := <-results:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("aucun résultat reçu")
+		return result{}
+	}
+}
+
+func TestServiceThumbnail(t *testing.T) {
+	var requests atomic.Int64
+	image := encodePNG(t, 400, 200)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write(image)
+	}))
+	defer srv.Close()
+
+	s := NewService(t.TempDir(), WithWorkers(2))
+	defer s.Close()
+
+	results := make(chan result, 3)
+	done := func(res fyne.Resource, err error) { results <- result{res, err} }
+	for i := 0; i < 3; i++ {
+		s.Thumbnail(srv.URL+"/queen.png", done)
+	}
+	for i := 0; i < 3; i++ {
+		if r := waitResult(t, results); r.err != nil || r.res == nil {
+			t.Fatalf("Thumbnail() = %v, %v", r.res, r.err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("%d téléchargements pour 3 demandes de la même image, attendu 1", got)
+	}
+
+	// Miniature en mémoire : le Callback est appelé immédiatement
+	called := false
+	s.Thumbnail(srv.URL+"/queen.png", func(res fyne.Resource, err error) { called = err == nil })
+	if !called {
+		t.Errorf("miniature en mémoire non servie immédiatement")
+	}
+
+	s.Original("", done)
+	if r := waitResult(t, results); !errors.Is(r.err, ErrNoURL) {
+		t.Errorf("Original(\"\") = %v, attendu %v", r.err, ErrNoURL)
+	}
+}
+
+func TestServiceCloseCancelsDownloads(t *testing.T) {
+	started := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	s := NewService("", WithWorkers(1))
+	results := make(chan result, 2)
+	done := func(res fyne.Resource, err error) { results <- result{res, err} }
+
+	s.Original(srv.URL+"/lent.png", done)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("téléchargement jamais commencé")
+	}
+	// Le seul goroutine de travail est occupé : cette demande attend dans la file
+	s.Original(srv.URL+"/en-attente.png", done)
+
+	closed := make(chan struct{})
+	go func() {
+		s.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() bloqué par un téléchargement en cours")
+	}
+
+	for i := 0; i < 2; i++ {
+		if r := waitResult(t, results); !errors.Is(r.err, context.Canceled) {
+			t.Errorf("demande %d après Close() = %v, attendu %v", i, r.err, context.Canceled)
+		}
+	}
+
+	// Une demande après la fermeture échoue sans bloquer
+	s.Thumbnail(srv.URL+"/apres.png", done)
+	if r := waitResult(t, results); !errors.Is(r.err, context.Canceled) {
+		t.Errorf("demande après Close() = %v, attendu %v", r.err, context.Canceled)
+	}
+}
@@ -0,0 +1,49 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+
+	// Formats acceptés pour les images des artistes
+	_ "image/gif"
+	_ "image/jpeg"
+
+	"golang.org/x/image/draw"
+)
+
+// checkImage vérifie qu'un contenu est une image dans un format connu
+func checkImage(data []byte) error {
+	_, _, err := image.DecodeConfig(bytes.NewReader(data))
+	return err
+}
+
+// thumbnail réduit une image pour que son plus grand côté mesure au plus size pixels
+// et l'encode en PNG
+func thumbnail(data []byte, size int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width >= height {
+			height = max(1, height*size/width)
+			width = size
+		} else {
+			width = max(1, width*size/height)
+			height = size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,58 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+)
+
+// encodePNG crée une image PNG unie de la taille donnée
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestThumbnailSize(t *testing.T) {
+	tests := []struct {
+		name                  string
+		width, height         int
+		wantWidth, wantHeight int
+	}{
+		{"paysage", 400, 200, 160, 80},
+		{"portrait", 100, 400, 40, 160},
+		{"carré", 320, 320, 160, 160},
+		{"déjà petite", 50, 30, 50, 30},
+		{"très étroite", 1000, 1, 160, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := thumbnail(encodePNG(t, tt.width, tt.height), DefaultThumbnailSize)
+			if err != nil {
+				t.Fatalf("thumbnail(): %v", err)
+			}
+			config, err := png.DecodeConfig(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("miniature illisible: %v", err)
+			}
+			if config.Width != tt.wantWidth || config.Height != tt.wantHeight {
+				t.Errorf("thumbnail(%dx%d) = %dx%d, attendu %dx%d",
+					tt.width, tt.height, config.Width, config.Height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestThumbnailRejectsInvalidImage(t *testing.T) {
+	if _, err := thumbnail([]byte("pas une image"), DefaultThumbnailSize); err == nil {
+		t.Errorf("thumbnail() sans erreur pour un contenu qui n'est pas une image")
+	}
+	if err := checkImage([]byte("pas une image")); err == nil {
+		t.Errorf("checkImage() sans erreur pour un contenu qui n'est pas une image")
+	}
+}
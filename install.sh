@@ -50,7 +50,7 @@ echo "✅ Installation terminée!"
 echo ""
 echo "📝 Prochaines étapes:"
 echo "   1. Copiez tous les fichiers .go dans leurs dossiers respectifs"
-echo "   2. Lancez l'application avec: go run main.go"
+echo "   2. Lancez l'application avec: go run ."
 echo "   3. Ou compilez avec: go build -o groupie-tracker"
 echo ""
 echo "📚 Structure des fichiers:"
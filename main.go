@@ -1,12 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"groupie-tracker/api"
+	"groupie-tracker/datasource"
+	"groupie-tracker/fuzzy"
+	"groupie-tracker/geo"
+	"groupie-tracker/images"
 	"groupie-tracker/models"
 	"groupie-tracker/services"
 	"groupie-tracker/ui"
 	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -19,10 +29,30 @@ import (
 // App représente l'application principale
 type App struct {
 	window        fyne.Window
-	apiClient     *api.Client
+	source        datasource.DataSource
 	searchService *services.SearchService
 	data          *models.APIData
 	currentView   string
+	mainContent   *fyne.Container
+	statusLabel   *widget.Label
+
+	// Annulation du chargement en cours (fermeture de la fenêtre ou rechargement)
+	ctx        context.Context
+	cancel     context.CancelFunc
+	loadMu     sync.Mutex
+	cancelLoad context.CancelFunc
+
+	// Rechargement périodique (nil si désactivé)
+	refresher *datasource.Refresher
+
+	// Images des artistes (nil si désactivées)
+	images *images.Service
+
+	// Géocodage hors ligne des lieux de concerts
+	geocoder *geo.Geocoder
+
+	// Tolérance aux fautes de frappe de la recherche (-search-typos)
+	searchTolerance fuzzy.Tolerance
 
 	// Vues
 	spotifyView *ui.SpotifyView
@@ -31,16 +61,58 @@ type App struct {
 }
 
 func main() {
+	cfg, err := parseConfig(os.Args[1:])
+	if err != nil {
+		log.Fatalf("❌ Configuration invalide: %v\n", err)
+	}
+
+	source, err := cfg.dataSource()
+	if err != nil {
+		log.Fatalf("❌ %v\n", err)
+	}
+
 	myApp := app.New()
 	myApp.Settings().SetTheme(theme.DarkTheme())
 
 	window := myApp.NewWindow("Groupie Tracker - Instagram Style")
 	window.Resize(fyne.NewSize(1200, 800))
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	application := &App{
 		window:      window,
-		apiClient:   api.NewClient(),
+		source:      source,
+		images:      cfg.imageService(),
+		geocoder:    geo.NewGeocoder(),
 		currentView: "spotify",
+		ctx:         ctx,
+		cancel:      cancel,
+
+		searchTolerance: cfg.searchTolerance(),
+	}
+
+	// Annuler les requêtes en cours à la fermeture de la fenêtre
+	window.SetOnClosed(func() {
+		cancel()
+		if application.images != nil {
+			application.images.Close()
+		}
+		cfg.closeRecorder()
+	})
+
+	// Rechargement périodique pour les instances qui restent ouvertes longtemps
+	if cfg.Refresh > 0 {
+		application.refresher = datasource.NewRefresher(application.source, cfg.Refresh, nil)
+		application.refresher.OnUpdate(application.applyUpdate)
+		go application.refresher.Run(ctx)
+		log.Printf("⏱️ Rechargement automatique toutes les %s\n", cfg.Refresh)
+	}
+
+	// Statistiques des requêtes exposées pour Prometheus
+	if cfg.Metrics != "" {
+		if metrics := application.metrics(); metrics != nil {
+			go serveMetrics(cfg.Metrics, metrics)
+		}
 	}
 
 	// Créer l'interface principale
@@ -53,32 +125,179 @@ func main() {
 	window.ShowAndRun()
 }
 
-// loadData charge toutes les données de l'API
+// loadData charge toutes les données depuis la source.
+// Un chargement déjà en cours est annulé au profit du nouveau.
 func (a *App) loadData() {
-	log.Println("🔄 Chargement des données...")
+	a.loadMu.Lock()
+	if a.cancelLoad != nil {
+		a.cancelLoad()
+	}
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.cancelLoad = cancel
+	a.loadMu.Unlock()
+	defer cancel()
+
+	log.Printf("🔄 Chargement des données depuis %s...\n", a.source.Name())
 
-	data, err := a.apiClient.LoadAllData()
+	data, err := a.source.Reload(ctx)
 	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			log.Println("⏹️ Chargement annulé")
+			return
+		}
+		a.updateStatus()
 		log.Printf("❌ Erreur lors du chargement: %v\n", err)
-		a.showError("Erreur de chargement des données. Veuillez vérifier votre connexion.")
+		a.showError("Erreur de chargement des données. " + api.Describe(err))
 		return
 	}
 
-	a.data = data
-	a.searchService = services.NewSearchService(data)
+	a.updateStatus()
+	if a.refresher != nil {
+		a.refresher.Store(data)
+	}
+	a.setData(data)
+}
+
+// setData remplace les données affichées et recrée les vues.
+// Le remplacement se fait en une fois sur le thread de l'interface.
+func (a *App) setData(data *models.APIData) {
+	searchService := services.NewSearchService(data)
+	searchService.SetTolerance(a.searchTolerance)
+	for _, issue := range searchService.Catalog().Issues() {
+		log.Printf("⚠️ Données incohérentes: %s\n", issue)
+	}
 
 	// Initialiser les vues
-	a.spotifyView = ui.NewSpotifyView(a.window, a.searchService, a.data)
-	a.mapView = ui.NewMapView(a.window, a.searchService, a.data)
-	a.shazamView = ui.NewShazamView(a.window, a.searchService, a.data)
+	spotifyView := ui.NewSpotifyView(a.window, searchService, data)
+	mapView := ui.NewMapView(a.window, searchService, data)
+	mapView.SetGeocoder(a.geocoder)
+	shazamView := ui.NewShazamView(a.window, searchService, data)
+	spotifyView.SetConcertRefresher(a.refreshConcerts)
+	shazamView.SetConcertRefresher(a.refreshConcerts)
+	if a.images != nil {
+		spotifyView.SetImageLoader(a.images.Thumbnail)
+		shazamView.SetImageLoader(a.images.Thumbnail)
+	}
+	if source, ok := a.source.(*datasource.Overridden); ok {
+		spotifyView.SetProvenance(source.Provenance())
+		shazamView.SetProvenance(source.Provenance())
+	}
+
+	report := a.geocoder.Resolve(data.Relations)
+	for _, key := range report.Unresolved {
+		log.Printf("⚠️ Lieu non localisé: %s\n", key)
+	}
 
 	log.Printf("✅ Données chargées: %d artistes\n", len(data.Artists))
+	log.Printf("🌐 Géocodage: %s\n", report.Summary())
+
+	fyne.Do(func() {
+		a.data = data
+		a.searchService = searchService
+		a.spotifyView = spotifyView
+		a.mapView = mapView
+		a.shazamView = shazamView
+		a.switchView(a.currentView, a.mainContent)
+	})
+}
+
+// applyUpdate affiche les données trouvées par le rechargement périodique
+func (a *App) applyUpdate(data *models.APIData, diff *models.DatasetDiff) {
+	summary := diff.Summary()
+	log.Printf("🔄 Nouvelles données: %s\n", summary)
+
+	for _, artist := range diff.AddedArtists {
+		log.Printf("  ➕ %s\n", artist.Name)
+	}
+	for _, artist := range diff.RemovedArtists {
+		log.Printf("  ➖ %s\n", artist.Name)
+	}
+	for _, change := range diff.MemberChanges {
+		log.Printf("  👥 %s: +%v -%v\n", change.ArtistName, change.Joined, change.Left)
+	}
+
+	a.setData(data)
+	a.setStatusText(fmt.Sprintf("🔄 Mis à jour à %s: %s", time.Now().Format("15:04"), summary))
+
+	fyne.CurrentApp().SendNotification(fyne.NewNotification("Groupie Tracker", "Données mises à jour: "+summary))
+}
+
+// refreshConcerts recharge la relation d'un seul artiste depuis la source
+func (a *App) refreshConcerts(artistID int) error {
+	relation, err := a.source.Relation(a.ctx, artistID)
+	if err != nil {
+		log.Printf("❌ Erreur lors de l'actualisation des concerts de %d: %v\n", artistID, err)
+		return err
+	}
+
+	// Le service de recherche est lu par l'interface : la mise à jour se fait sur son thread
+	fyne.DoAndWait(func() {
+		a.searchService.UpdateRelation(*relation)
+	})
+	log.Printf("🔄 Concerts de l'artiste %d actualisés\n", artistID)
+	return nil
+}
+
+// metrics retourne les statistiques du client API (nil si la source n'est pas l'API)
+func (a *App) metrics() *api.Metrics {
+	source := a.source
+	if wrapper, ok := source.(datasource.Unwrapper); ok {
+		source = wrapper.Unwrap()
+	}
+	if source, ok := source.(*datasource.HTTP); ok {
+		return source.Client().Metrics()
+	}
+	return nil
+}
+
+// serveMetrics sert /metrics sur addr au format texte de Prometheus
+func serveMetrics(addr string, metrics *api.Metrics) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	log.Printf("📈 Statistiques disponibles sur http://%s/metrics\n", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("❌ Serveur de statistiques arrêté: %v\n", err)
+	}
+}
+
+// updateStatus affiche l'état du dernier chargement si la source sait le décrire
+func (a *App) updateStatus() {
+	if reporter, ok := a.source.(datasource.StatusReporter); ok {
+		if status := reporter.Status(); status != "" {
+			a.setStatusText(status)
+			log.Println(status)
+		}
+	}
+}
+
+// setStatusText affiche un message d'état dans la navigation
+func (a *App) setStatusText(status string) {
+	if a.statusLabel == nil {
+		return
+	}
+
+	fyne.Do(func() {
+		a.statusLabel.SetText(status)
+	})
+}
+
+// reloadData vide les données affichées puis relance le chargement
+func (a *App) reloadData() {
+	a.data = nil
+	a.spotifyView = nil
+	a.mapView = nil
+	a.shazamView = nil
+	a.switchView(a.currentView, a.mainContent)
+
+	go a.loadData()
 }
 
 // createMainUI crée l'interface principale
 func (a *App) createMainUI() *fyne.Container {
 	// Container pour le contenu principal
 	mainContent := container.NewStack()
+	a.mainContent = mainContent
 
 	// Navigation Instagram-style
 	navigation := a.createNavigation(mainContent)
@@ -156,11 +375,39 @@ func (a *App) createNavigation(mainContent *fyne.Container) *fyne.Container {
 
 	separator2 := widget.NewSeparator()
 
+	// Rechargement manuel des données
+	reloadBtn := widget.NewButtonWithIcon("Recharger", theme.ViewRefreshIcon(), func() {
+		a.reloadData()
+	})
+
+	// Rapport de qualité des données chargées
+	qualityBtn := widget.NewButtonWithIcon("Qualité des données", theme.InfoIcon(), func() {
+		if a.data == nil {
+			return
+		}
+		ui.ShowQualityReport(a.window, a.data)
+	})
+
+	// Statistiques des requêtes à l'API
+	diagnosticsBtn := widget.NewButtonWithIcon("Diagnostics", theme.ComputerIcon(), func() {
+		metrics := a.metrics()
+		if metrics == nil {
+			a.showError("Diagnostics indisponibles: les données ne viennent pas de l'API.")
+			return
+		}
+		ui.ShowDiagnostics(a.window, metrics)
+	})
+
 	// Informations en bas
 	infoLabel := widget.NewLabel("API: Groupie Tracker")
 	infoLabel.Alignment = fyne.TextAlignCenter
 	infoLabel.TextStyle = fyne.TextStyle{Italic: true}
 
+	// État du dernier chargement ("relations chargées, dates indisponibles")
+	a.statusLabel = widget.NewLabel("")
+	a.statusLabel.Alignment = fyne.TextAlignCenter
+	a.statusLabel.Wrapping = fyne.TextWrapWord
+
 	// Container de navigation
 	navContent := container.NewVBox(
 		container.NewPadded(title),
@@ -173,7 +420,11 @@ func (a *App) createNavigation(mainContent *fyne.Container) *fyne.Container {
 		container.NewPadded(shazamContainer),
 		layout.NewSpacer(),
 		separator2,
+		container.NewPadded(reloadBtn),
+		container.NewPadded(qualityBtn),
+		container.NewPadded(diagnosticsBtn),
 		container.NewPadded(infoLabel),
+		a.statusLabel,
 	)
 
 	// Définir une largeur fixe pour la navigation
@@ -210,6 +461,7 @@ func (a *App) switchView(view string, mainContent *fyne.Container) {
 	case "map":
 		if a.mapView == nil {
 			a.mapView = ui.NewMapView(a.window, a.searchService, a.data)
+			a.mapView.SetGeocoder(a.geocoder)
 		}
 		newView = a.mapView.Render()
 
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DateLayout est le format des dates de l'API ("23-08-2019")
+const DateLayout = "02-01-2006"
+
+// DisplayLayout est le format des dates affichées dans l'application
+const DisplayLayout = "02/01/2006"
+
+// ConcertDate est une date de concert analysée.
+// Raw conserve la chaîne reçue de l'API et Marked indique le préfixe "*".
+type ConcertDate struct {
+	Time   time.Time
+	Raw    string
+	Marked bool
+	Valid  bool
+}
+
+// ParseConcertDate analyse une date de l'API, avec ou sans astérisque.
+// En cas d'erreur, la date retournée garde Raw et Marked avec Valid à false.
+func ParseConcertDate(raw string) (ConcertDate, error) {
+	value := strings.TrimSpace(raw)
+	date := ConcertDate{Raw: raw, Marked: strings.HasPrefix(value, "*")}
+
+	t, err := time.Parse(DateLayout, strings.TrimLeft(value, "*"))
+	if err != nil {
+		return date, err
+	}
+
+	date.Time = t
+	date.Valid = true
+	return date, nil
+}
+
+// ParseConcertDates analyse plusieurs dates et les trie chronologiquement.
+// Les dates illisibles sont conservées en fin de liste, dans leur ordre d'origine.
+func ParseConcertDates(raws []string) []ConcertDate {
+	dates := make([]ConcertDate, 0, len(raws))
+	for _, raw := range raws {
+		date, _ := ParseConcertDate(raw)
+		dates = append(dates, date)
+	}
+	SortConcertDates(dates)
+	return dates
+}
+
+// SortConcertDates trie des dates chronologiquement, les dates illisibles en dernier
+func SortConcertDates(dates []ConcertDate) {
+	sort.SliceStable(dates, func(i, j int) bool {
+		if dates[i].Valid != dates[j].Valid {
+			return dates[i].Valid
+		}
+		return dates[i].Time.Before(dates[j].Time)
+	})
+}
+
+// String retourne la date au format d'affichage (la chaîne d'origine si illisible)
+func (d ConcertDate) String() string {
+	if !d.Valid {
+		return d.Raw
+	}
+	return d.Time.Format(DisplayLayout)
+}
+
+// ParsedDates retourne les dates du concert analysées et triées chronologiquement
+func (c Concert) ParsedDates() []ConcertDate {
+	return ParseConcertDates(c.Dates)
+}
@@ -0,0 +1,189 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConcertChange représente un concert ajouté ou annulé
+type ConcertChange struct {
+	ArtistID   int
+	ArtistName string
+	Location   string
+	Date       string
+}
+
+// MemberChange représente l'évolution des membres d'un artiste
+type MemberChange struct {
+	ArtistID   int
+	ArtistName string
+	Joined     []string
+	Left       []string
+}
+
+// DatasetDiff décrit les différences entre deux jeux de données
+type DatasetDiff struct {
+	AddedArtists      []Artist
+	RemovedArtists    []Artist
+	NewConcerts       []ConcertChange
+	CancelledConcerts []ConcertChange
+	MemberChanges     []MemberChange
+}
+
+// Empty indique si les deux jeux de données sont équivalents
+func (d *DatasetDiff) Empty() bool {
+	return len(d.AddedArtists) == 0 &&
+		len(d.RemovedArtists) == 0 &&
+		len(d.NewConcerts) == 0 &&
+		len(d.CancelledConcerts) == 0 &&
+		len(d.MemberChanges) == 0
+}
+
+// Summary retourne un résumé court ("2 artistes ajoutés, 5 nouveaux concerts")
+func (d *DatasetDiff) Summary() string {
+	if d.Empty() {
+		return "aucun changement"
+	}
+
+	var parts []string
+	if n := len(d.AddedArtists); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d artiste(s) ajouté(s)", n))
+	}
+	if n := len(d.RemovedArtists); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d artiste(s) retiré(s)", n))
+	}
+	if n := len(d.NewConcerts); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d nouveau(x) concert(s)", n))
+	}
+	if n := len(d.CancelledConcerts); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d concert(s) annulé(s)", n))
+	}
+	if n := len(d.MemberChanges); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d groupe(s) avec des membres modifiés", n))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Diff compare deux jeux de données, les artistes et relations étant associés par ID
+func Diff(old, new *APIData) *DatasetDiff {
+	diff := &DatasetDiff{}
+	if old == nil {
+		old = &APIData{}
+	}
+	if new == nil {
+		new = &APIData{}
+	}
+
+	oldArtists := artistsByID(old.Artists)
+	newArtists := artistsByID(new.Artists)
+
+	for _, artist := range new.Artists {
+		previous, ok := oldArtists[artist.ID]
+		if !ok {
+			diff.AddedArtists = append(diff.AddedArtists, artist)
+			continue
+		}
+
+		joined := missingFrom(artist.Members, previous.Members)
+		left := missingFrom(previous.Members, artist.Members)
+		if len(joined) > 0 || len(left) > 0 {
+			diff.MemberChanges = append(diff.MemberChanges, MemberChange{
+				ArtistID:   artist.ID,
+				ArtistName: artist.Name,
+				Joined:     joined,
+				Left:       left,
+			})
+		}
+	}
+
+	for _, artist := range old.Artists {
+		if _, ok := newArtists[artist.ID]; !ok {
+			diff.RemovedArtists = append(diff.RemovedArtists, artist)
+		}
+	}
+
+	// Concerts : comparaison des couples (lieu, date) de chaque artiste
+	oldConcerts := concertsByArtist(old, oldArtists)
+	newConcerts := concertsByArtist(new, newArtists)
+
+	for id, concerts := range newConcerts {
+		previous := oldConcerts[id]
+		for key, concert := range concerts {
+			if _, ok := previous[key]; !ok {
+				diff.NewConcerts = append(diff.NewConcerts, concert)
+			}
+		}
+	}
+	for id, concerts := range oldConcerts {
+		current := newConcerts[id]
+		for key, concert := range concerts {
+			if _, ok := current[key]; !ok {
+				diff.CancelledConcerts = append(diff.CancelledConcerts, concert)
+			}
+		}
+	}
+
+	sortConcertChanges(diff.NewConcerts)
+	sortConcertChanges(diff.CancelledConcerts)
+
+	return diff
+}
+
+// artistsByID indexe les artistes par ID
+func artistsByID(artists []Artist) map[int]Artist {
+	index := make(map[int]Artist, len(artists))
+	for _, artist := range artists {
+		index[artist.ID] = artist
+	}
+	return index
+}
+
+// concertsByArtist liste les concerts de chaque artiste, indexés par "lieu|date"
+func concertsByArtist(data *APIData, artists map[int]Artist) map[int]map[string]ConcertChange {
+	concerts := make(map[int]map[string]ConcertChange)
+	for _, relation := range data.Relations {
+		byKey := make(map[string]ConcertChange)
+		for location, dates := range relation.DatesLocations {
+			for _, date := range dates {
+				byKey[location+"|"+date] = ConcertChange{
+					ArtistID:   relation.ID,
+					ArtistName: artists[relation.ID].Name,
+					Location:   location,
+					Date:       date,
+				}
+			}
+		}
+		concerts[relation.ID] = byKey
+	}
+	return concerts
+}
+
+// missingFrom retourne les éléments de a absents de b
+func missingFrom(a, b []string) []string {
+	present := make(map[string]bool, len(b))
+	for _, v := range b {
+		present[v] = true
+	}
+
+	var missing []string
+	for _, v := range a {
+		if !present[v] {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// sortConcertChanges trie les changements par artiste, lieu puis date
+func sortConcertChanges(changes []ConcertChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].ArtistID != changes[j].ArtistID {
+			return changes[i].ArtistID < changes[j].ArtistID
+		}
+		if changes[i].Location != changes[j].Location {
+			return changes[i].Location < changes[j].Location
+		}
+		return changes[i].Date < changes[j].Date
+	})
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	base := &APIData{
+		Artists: []Artist{
+			{ID: 1, Name: "Queen", Members: []string{"Freddie Mercury", "Brian May"}},
+			{ID: 2, Name: "SOJA", Members: []string{"Jacob Hemphill"}},
+		},
+		Relations: []Relation{
+			{ID: 1, DatesLocations: map[string][]string{"london-uk": {"14-07-1986"}}},
+			{ID: 2, DatesLocations: map[string][]string{"paris-france": {"01-01-2020"}}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		old     *APIData
+		new     *APIData
+		want    DatasetDiff
+		summary string
+	}{
+		{
+			name:    "identiques",
+			old:     base,
+			new:     base,
+			summary: "aucun changement",
+		},
+		{
+			name: "artiste ajouté avec ses concerts",
+			old:  &APIData{Artists: base.Artists[:1], Relations: base.Relations[:1]},
+			new:  base,
+			want: DatasetDiff{
+				AddedArtists: []Artist{base.Artists[1]},
+				NewConcerts:  []ConcertChange{{ArtistID: 2, ArtistName: "SOJA", Location: "paris-france", Date: "01-01-2020"}},
+			},
+			summary: "1 artiste(s) ajouté(s), 1 nouveau(x) concert(s)",
+		},
+		{
+			name: "artiste retiré",
+			old:  base,
+			new:  &APIData{Artists: base.Artists[:1], Relations: base.Relations[:1]},
+			want: DatasetDiff{
+				RemovedArtists:    []Artist{base.Artists[1]},
+				CancelledConcerts: []ConcertChange{{ArtistID: 2, ArtistName: "SOJA", Location: "paris-france", Date: "01-01-2020"}},
+			},
+		},
+		{
+			name: "membres et dates modifiés",
+			old:  base,
+			new: &APIData{
+				Artists: []Artist{
+					{ID: 1, Name: "Queen", Members: []string{"Freddie Mercury", "Roger Taylor"}},
+					base.Artists[1],
+				},
+				Relations: []Relation{
+					{ID: 1, DatesLocations: map[string][]string{"london-uk": {"14-07-1986", "15-07-1986"}}},
+					base.Relations[1],
+				},
+			},
+			want: DatasetDiff{
+				MemberChanges: []MemberChange{{ArtistID: 1, ArtistName: "Queen", Joined: []string{"Roger Taylor"}, Left: []string{"Brian May"}}},
+				NewConcerts:   []ConcertChange{{ArtistID: 1, ArtistName: "Queen", Location: "london-uk", Date: "15-07-1986"}},
+			},
+		},
+		{
+			name: "premier chargement",
+			old:  nil,
+			new:  &APIData{Artists: base.Artists[:1]},
+			want: DatasetDiff{AddedArtists: []Artist{base.Artists[0]}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.old, tt.new)
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Diff() = %+v, attendu %+v", *got, tt.want)
+			}
+			if got.Empty() != reflect.DeepEqual(tt.want, DatasetDiff{}) {
+				t.Errorf("Empty() = %v", got.Empty())
+			}
+			if tt.summary != "" && got.Summary() != tt.summary {
+				t.Errorf("Summary() = %q, attendu %q", got.Summary(), tt.summary)
+			}
+		})
+	}
+}
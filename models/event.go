@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Event est un concert unique : un artiste, un lieu et une date
+type Event struct {
+	ID         string // identifiant stable ("12-los_angeles-usa-2019-08-20")
+	ArtistID   int
+	ArtistName string
+	Place      Place
+	Date       ConcertDate
+}
+
+// EventID calcule l'identifiant stable d'un concert.
+// Il ne dépend ni de l'ordre des données ni du marqueur "*" de la date,
+// pour rester le même d'un chargement à l'autre.
+func EventID(artistID int, placeKey string, date ConcertDate) string {
+	day := strings.TrimLeft(strings.TrimSpace(date.Raw), "*")
+	if date.Valid {
+		day = date.Time.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%d-%s-%s", artistID, strings.ToLower(strings.TrimSpace(placeKey)), day)
+}
+
+// ArtistIDFromEventID retourne l'ID d'artiste contenu dans un identifiant de concert
+func ArtistIDFromEventID(id string) (int, bool) {
+	prefix, _, ok := strings.Cut(id, "-")
+	if !ok {
+		return 0, false
+	}
+	var artistID int
+	if _, err := fmt.Sscanf(prefix, "%d", &artistID); err != nil {
+		return 0, false
+	}
+	return artistID, true
+}
+
+// Events retourne un concert par date, triés chronologiquement.
+// Une date répétée pour le même lieu ne donne qu'un seul concert.
+func (c Concert) Events() []Event {
+	events := make([]Event, 0, len(c.Dates))
+	seen := make(map[string]bool, len(c.Dates))
+	for _, date := range c.ParsedDates() {
+		id := EventID(c.ArtistID, c.Location, date)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		events = append(events, Event{
+			ID:         id,
+			ArtistID:   c.ArtistID,
+			ArtistName: c.ArtistName,
+			Place:      c.Place,
+			Date:       date,
+		})
+	}
+	return events
+}
+
+// SortEvents trie des concerts chronologiquement (dates illisibles en dernier),
+// puis par artiste et par lieu pour un ordre déterministe
+func SortEvents(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Date.Valid != b.Date.Valid {
+			return a.Date.Valid
+		}
+		if !a.Date.Time.Equal(b.Date.Time) {
+			return a.Date.Time.Before(b.Date.Time)
+		}
+		return a.ID < b.ID
+	})
+}
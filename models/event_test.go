@@ -0,0 +1,68 @@
+package models
+
+import "testing"
+
+func mustParseDate(t *testing.T, raw string) ConcertDate {
+	t.Helper()
+	date, _ := ParseConcertDate(raw)
+	return date
+}
+
+func TestEventID(t *testing.T) {
+	tests := []struct {
+		name     string
+		artistID int
+		place    string
+		date     string
+		want     string
+	}{
+		{"date valide", 12, "los_angeles-usa", "20-08-2019", "12-los_angeles-usa-2019-08-20"},
+		{"astérisque ignoré", 12, "los_angeles-usa", "*20-08-2019", "12-los_angeles-usa-2019-08-20"},
+		{"clé normalisée", 12, " Los_Angeles-USA ", "20-08-2019", "12-los_angeles-usa-2019-08-20"},
+		{"date illisible", 3, "paris-france", "*bientôt", "3-paris-france-bientôt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EventID(tt.artistID, tt.place, mustParseDate(t, tt.date))
+			if got != tt.want {
+				t.Errorf("EventID(%d, %q, %q) = %q, attendu %q", tt.artistID, tt.place, tt.date, got, tt.want)
+			}
+			if id, ok := ArtistIDFromEventID(got); !ok || id != tt.artistID {
+				t.Errorf("ArtistIDFromEventID(%q) = %d, %v, attendu %d", got, id, ok, tt.artistID)
+			}
+		})
+	}
+}
+
+func TestArtistIDFromEventIDInvalid(t *testing.T) {
+	for _, id := range []string{"", "abc", "abc-paris-france-2019-08-20", "-paris"} {
+		if _, ok := ArtistIDFromEventID(id); ok {
+			t.Errorf("ArtistIDFromEventID(%q) accepté, attendu un refus", id)
+		}
+	}
+}
+
+func TestConcertEvents(t *testing.T) {
+	concert := Concert{
+		ArtistID:   1,
+		ArtistName: "Queen",
+		Location:   "london-uk",
+		Dates:      []string{"*14-07-1986", "12-07-1986", "14-07-1986", "bientôt"},
+	}
+
+	events := concert.Events()
+	want := []string{
+		"1-london-uk-1986-07-12",
+		"1-london-uk-1986-07-14", // la date répétée (avec et sans astérisque) ne compte qu'une fois
+		"1-london-uk-bientôt",
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Events() = %d concerts, attendu %d: %+v", len(events), len(want), events)
+	}
+	for i, event := range events {
+		if event.ID != want[i] {
+			t.Errorf("Events()[%d].ID = %q, attendu %q", i, event.ID, want[i])
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Member est un musicien, identifié indépendamment des groupes dans lesquels il apparaît
+type Member struct {
+	ID        string // identité normalisée ("freddie_mercury")
+	Name      string // nom affiché (première graphie rencontrée)
+	ArtistIDs []int  // groupes dont il a fait partie, triés par ID
+}
+
+// Bands retourne le nombre de groupes du membre
+func (m Member) Bands() int {
+	return len(m.ArtistIDs)
+}
+
+// Lettres sans décomposition Unicode, ramenées à leur forme de base pour comparer les noms
+// (les autres accents sont retirés après décomposition NFD)
+var letterFolding = map[rune]string{
+	'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d", 'ð': "d",
+	'ß': "ss", 'þ': "th", 'ı': "i",
+}
+
+// MemberID calcule l'identité normalisée d'un nom de membre :
+// casse, accents, ponctuation et espaces multiples sont ignorés
+// ("Freddie  Mercury", "freddie mercury" et "Freddie-Mercury" donnent "freddie_mercury").
+func MemberID(name string) string {
+	var b strings.Builder
+	pending := false
+	for _, r := range norm.NFD.String(strings.ToLower(name)) {
+		if unicode.Is(unicode.Mn, r) {
+			// Accent détaché de sa lettre par la décomposition
+			continue
+		}
+		folded, isFolded := letterFolding[r]
+		if !isFolded && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			// Les séparateurs consécutifs ne comptent qu'une fois
+			pending = b.Len() > 0
+			continue
+		}
+		if pending {
+			b.WriteByte('_')
+			pending = false
+		}
+		if isFolded {
+			b.WriteString(folded)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,34 @@
+package models
+
+import "testing"
+
+func TestMemberID(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Freddie Mercury", "freddie_mercury"},
+		{"freddie  mercury", "freddie_mercury"},
+		{"Freddie-Mercury", "freddie_mercury"},
+		{"  Freddie Mercury  ", "freddie_mercury"},
+		{"Paweł Mąciwoda", "pawel_maciwoda"},
+		{"Björk Guðmundsdóttir", "bjork_gudmundsdottir"},
+		{"Ryan \"Byrd\" Berty", "ryan_byrd_berty"},
+		{"Patrick O'Shea", "patrick_o_shea"},
+		{"Barış Manço", "baris_manco"},
+		{"Ağır Şekil", "agir_sekil"},
+		{"Ștefan Bănică", "stefan_banica"},
+		{"Țiriac", "tiriac"},
+		{"Đorđe Balašević", "dorde_balasevic"},
+		{"Øyvind Ståle", "oyvind_stale"},
+		{"Straße", "strasse"},
+		{"Slash", "slash"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := MemberID(tt.name); got != tt.want {
+			t.Errorf("MemberID(%q) = %q, attendu %q", tt.name, got, tt.want)
+		}
+	}
+}
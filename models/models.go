@@ -31,10 +31,10 @@ type Relation struct {
 
 // APIData contient toutes les données de l'API
 type APIData struct {
-	Artists   []Artist
-	Locations []Location
-	Dates     []Date
-	Relations []Relation
+	Artists   []Artist   `json:"artists"`
+	Locations []Location `json:"locations"`
+	Dates     []Date     `json:"dates"`
+	Relations []Relation `json:"relations"`
 }
 
 // Concert représente un concert avec toutes ses informations
@@ -42,12 +42,48 @@ type Concert struct {
 	ArtistID   int
 	ArtistName string
 	Location   string
+	Place      Place
 	Dates      []string
 }
 
+// ResultKind est la nature d'un résultat de recherche
+type ResultKind int
+
+const (
+	KindArtist   ResultKind = iota // Nom d'artiste
+	KindMember                     // Membre d'un groupe
+	KindAlbum                      // Date du premier album
+	KindLocation                   // Lieu de concert
+)
+
+// String retourne le nom du type de résultat ("artist", "member", ...)
+func (k ResultKind) String() string {
+	switch k {
+	case KindArtist:
+		return "artist"
+	case KindMember:
+		return "member"
+	case KindAlbum:
+		return "album"
+	case KindLocation:
+		return "location"
+	default:
+		return "unknown"
+	}
+}
+
+// Span est une plage d'octets [Start, End) d'un texte
+type Span struct {
+	Start int
+	End   int
+}
+
 // SearchResult représente un résultat de recherche
 type SearchResult struct {
-	Type   string // "artist", "member", "location", "date"
-	Value  string
-	Artist *Artist
+	Kind    ResultKind
+	Value   string  // texte affiché
+	Field   string  // champ de l'API qui correspond ("name", "members", "firstAlbum", "locations")
+	Matches []Span  // plages de Value qui correspondent à la recherche (vide si la correspondance n'y apparaît pas)
+	Score   float64 // pertinence entre 0 et 1 (1 = correspondance exacte)
+	Artist  *Artist
 }
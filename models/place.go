@@ -0,0 +1,27 @@
+package models
+
+import "strings"
+
+// Place est un lieu de concert structuré, obtenu à partir d'une clé de l'API
+// comme "north_carolina-usa" ou "los_angeles-usa"
+type Place struct {
+	Key         string // clé d'origine
+	City        string
+	Region      string // état ou province, si connu
+	Country     string // nom du pays
+	CountryCode string // code ISO 3166-1 alpha-2, vide si le pays est inconnu
+}
+
+// String retourne le lieu lisible ("Los Angeles, États-Unis")
+func (p Place) String() string {
+	var parts []string
+	for _, part := range []string{p.City, p.Region, p.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return p.Key
+	}
+	return strings.Join(parts, ", ")
+}
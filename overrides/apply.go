@@ -0,0 +1,375 @@
+package overrides
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"sort"
+	"strings"
+)
+
+// Champs suivis par la provenance
+const (
+	FieldName         = "name"
+	FieldImage        = "image"
+	FieldCreationDate = "creationDate"
+	FieldFirstAlbum   = "firstAlbum"
+	FieldMembers      = "members"
+	FieldConcerts     = "concerts"
+)
+
+// Provenance indique quelles valeurs viennent des surcharges plutôt que de l'API
+type Provenance struct {
+	fields   map[int]map[string]bool
+	added    map[int]bool
+	Warnings []string
+}
+
+// Overridden indique si un champ d'un artiste a été surchargé
+func (p *Provenance) Overridden(id int, field string) bool {
+	if p == nil {
+		return false
+	}
+	return p.added[id] || p.fields[id][field]
+}
+
+// Added indique si l'artiste n'existe que dans les surcharges
+func (p *Provenance) Added(id int) bool {
+	return p != nil && p.added[id]
+}
+
+// Fields retourne les champs surchargés d'un artiste, triés
+func (p *Provenance) Fields(id int) []string {
+	if p == nil {
+		return nil
+	}
+
+	var fields []string
+	for field := range p.fields[id] {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Artists retourne le nombre d'artistes modifiés ou ajoutés
+func (p *Provenance) Artists() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.fields)
+}
+
+func (p *Provenance) mark(id int, field string) {
+	if p.fields[id] == nil {
+		p.fields[id] = make(map[string]bool)
+	}
+	p.fields[id][field] = true
+}
+
+// Apply retourne une copie des données avec les surcharges appliquées.
+// Les données d'origine ne sont pas modifiées.
+func (o *Overrides) Apply(data *models.APIData) (*models.APIData, *Provenance) {
+	prov := &Provenance{
+		fields: make(map[int]map[string]bool),
+		added:  make(map[int]bool),
+	}
+	if data == nil {
+		return nil, prov
+	}
+
+	out := clone(data)
+
+	ids := make([]int, 0, len(o.artists))
+	for id := range o.artists {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		override := o.artists[id]
+
+		index := artistIndex(out, id)
+		if index < 0 {
+			if override.Name == nil {
+				prov.Warnings = append(prov.Warnings, fmt.Sprintf("artiste %d absent de l'API et sans nom: surcharge ignorée", id))
+				continue
+			}
+			out.Artists = append(out.Artists, models.Artist{ID: id})
+			index = len(out.Artists) - 1
+			prov.added[id] = true
+		}
+
+		applyArtist(&out.Artists[index], override, prov)
+		if len(override.AddConcerts) > 0 || len(override.RemoveConcerts) > 0 || prov.added[id] {
+			applyConcerts(out, id, override, prov)
+		}
+	}
+
+	return out, prov
+}
+
+// ApplyRelation applique les surcharges de concerts à une seule relation
+func (o *Overrides) ApplyRelation(relation *models.Relation) *models.Relation {
+	override, ok := o.artists[relation.ID]
+	if !ok || (len(override.AddConcerts) == 0 && len(override.RemoveConcerts) == 0) {
+		return relation
+	}
+
+	data := clone(&models.APIData{Relations: []models.Relation{*relation}})
+	applyConcerts(data, relation.ID, override, &Provenance{fields: make(map[int]map[string]bool)})
+	return &data.Relations[0]
+}
+
+// ApplyArtist applique les surcharges à un seul artiste
+func (o *Overrides) ApplyArtist(artist *models.Artist) *models.Artist {
+	override, ok := o.artists[artist.ID]
+	if !ok {
+		return artist
+	}
+
+	patched := *artist
+	patched.Members = append([]string(nil), artist.Members...)
+	applyArtist(&patched, override, &Provenance{fields: make(map[int]map[string]bool)})
+	return &patched
+}
+
+// applyArtist modifie les champs d'un artiste ; seuls les champs réellement changés
+// sont marqués dans la provenance
+func applyArtist(artist *models.Artist, override ArtistOverride, prov *Provenance) {
+	if override.Name != nil && *override.Name != artist.Name {
+		artist.Name = *override.Name
+		prov.mark(artist.ID, FieldName)
+	}
+	if override.Image != nil && *override.Image != artist.Image {
+		artist.Image = *override.Image
+		prov.mark(artist.ID, FieldImage)
+	}
+	if override.CreationDate != nil && *override.CreationDate != artist.CreationDate {
+		artist.CreationDate = *override.CreationDate
+		prov.mark(artist.ID, FieldCreationDate)
+	}
+	if override.FirstAlbum != nil && *override.FirstAlbum != artist.FirstAlbum {
+		artist.FirstAlbum = *override.FirstAlbum
+		prov.mark(artist.ID, FieldFirstAlbum)
+	}
+
+	members := append([]string(nil), artist.Members...)
+	if override.Members != nil {
+		members = append([]string(nil), override.Members...)
+	}
+	if len(override.RemoveMembers) > 0 {
+		members = without(members, override.RemoveMembers)
+	}
+	for _, member := range override.AddMembers {
+		if !contains(members, member) {
+			members = append(members, member)
+		}
+	}
+	if !equal(members, artist.Members) {
+		artist.Members = members
+		prov.mark(artist.ID, FieldMembers)
+	}
+}
+
+// applyConcerts modifie la relation d'un artiste. /locations et /dates ne sont modifiés
+// que pour les concerts ajoutés ou retirés : leur ordre et les marqueurs "*" de l'API
+// sont conservés.
+func applyConcerts(data *models.APIData, id int, override ArtistOverride, prov *Provenance) {
+	relation := relationFor(data, id)
+
+	var removedPlaces, addedPlaces []string
+	var removedDates, addedDates []string
+	for _, location := range sortedLocations(override.RemoveConcerts) {
+		current, ok := relation.DatesLocations[location]
+		if !ok {
+			continue
+		}
+
+		// Une liste vide retire tous les concerts du lieu
+		var remaining []string
+		if dates := override.RemoveConcerts[location]; len(dates) > 0 {
+			remaining = without(current, dates)
+		}
+		for _, date := range current {
+			if !contains(remaining, date) {
+				removedDates = append(removedDates, date)
+			}
+		}
+
+		if len(remaining) == 0 {
+			delete(relation.DatesLocations, location)
+			removedPlaces = append(removedPlaces, location)
+		} else {
+			relation.DatesLocations[location] = remaining
+		}
+	}
+	for _, location := range sortedLocations(override.AddConcerts) {
+		for _, date := range override.AddConcerts[location] {
+			current, known := relation.DatesLocations[location]
+			if contains(current, date) {
+				continue
+			}
+			relation.DatesLocations[location] = append(current, date)
+
+			// Comme dans l'API, "*" marque la première date d'un lieu
+			if !known {
+				addedPlaces = append(addedPlaces, location)
+				date = "*" + date
+			}
+			addedDates = append(addedDates, date)
+		}
+	}
+
+	if len(removedDates) == 0 && len(addedDates) == 0 {
+		return
+	}
+	prov.mark(id, FieldConcerts)
+
+	if location := locationFor(data, id); location != nil {
+		location.Locations = append(without(location.Locations, removedPlaces), addedPlaces...)
+	}
+	if date := dateFor(data, id); date != nil {
+		date.Dates = append(withoutDates(date.Dates, removedDates), addedDates...)
+	}
+}
+
+// relationFor retourne la relation d'un artiste, en la créant si nécessaire
+func relationFor(data *models.APIData, id int) *models.Relation {
+	for i := range data.Relations {
+		if data.Relations[i].ID == id {
+			if data.Relations[i].DatesLocations == nil {
+				data.Relations[i].DatesLocations = make(map[string][]string)
+			}
+			return &data.Relations[i]
+		}
+	}
+	data.Relations = append(data.Relations, models.Relation{ID: id, DatesLocations: make(map[string][]string)})
+	data.Locations = append(data.Locations, models.Location{ID: id})
+	data.Dates = append(data.Dates, models.Date{ID: id})
+	return &data.Relations[len(data.Relations)-1]
+}
+
+// locationFor retourne les lieux d'un artiste (nil si absents)
+func locationFor(data *models.APIData, id int) *models.Location {
+	for i := range data.Locations {
+		if data.Locations[i].ID == id {
+			return &data.Locations[i]
+		}
+	}
+	return nil
+}
+
+// dateFor retourne les dates d'un artiste (nil si absentes)
+func dateFor(data *models.APIData, id int) *models.Date {
+	for i := range data.Dates {
+		if data.Dates[i].ID == id {
+			return &data.Dates[i]
+		}
+	}
+	return nil
+}
+
+// artistIndex retourne la position d'un artiste (-1 si absent)
+func artistIndex(data *models.APIData, id int) int {
+	for i := range data.Artists {
+		if data.Artists[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// clone copie les données en profondeur
+func clone(data *models.APIData) *models.APIData {
+	out := &models.APIData{
+		Artists:   make([]models.Artist, len(data.Artists)),
+		Locations: make([]models.Location, len(data.Locations)),
+		Dates:     make([]models.Date, len(data.Dates)),
+		Relations: make([]models.Relation, len(data.Relations)),
+	}
+
+	for i, artist := range data.Artists {
+		artist.Members = append([]string(nil), artist.Members...)
+		out.Artists[i] = artist
+	}
+	for i, location := range data.Locations {
+		location.Locations = append([]string(nil), location.Locations...)
+		out.Locations[i] = location
+	}
+	for i, date := range data.Dates {
+		date.Dates = append([]string(nil), date.Dates...)
+		out.Dates[i] = date
+	}
+	for i, relation := range data.Relations {
+		datesLocations := make(map[string][]string, len(relation.DatesLocations))
+		for location, dates := range relation.DatesLocations {
+			datesLocations[location] = append([]string(nil), dates...)
+		}
+		relation.DatesLocations = datesLocations
+		out.Relations[i] = relation
+	}
+
+	return out
+}
+
+// contains indique si values contient value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// without retourne values sans les éléments de removed
+func without(values, removed []string) []string {
+	var kept []string
+	for _, v := range values {
+		if !contains(removed, v) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// equal indique si deux listes contiennent les mêmes valeurs dans le même ordre
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutDates retire une entrée de /dates par date retirée, avec ou sans marqueur "*"
+func withoutDates(dates, removed []string) []string {
+	pending := make(map[string]int, len(removed))
+	for _, date := range removed {
+		pending[date]++
+	}
+
+	var kept []string
+	for _, date := range dates {
+		if plain := strings.TrimPrefix(date, "*"); pending[plain] > 0 {
+			pending[plain]--
+			continue
+		}
+		kept = append(kept, date)
+	}
+	return kept
+}
+
+// sortedLocations retourne les lieux d'une relation triés
+func sortedLocations(datesLocations map[string][]string) []string {
+	locations := make([]string, 0, len(datesLocations))
+	for location := range datesLocations {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	return locations
+}
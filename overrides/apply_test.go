@@ -0,0 +1,231 @@
+package overrides
+
+import (
+	"groupie-tracker/models"
+	"reflect"
+	"testing"
+)
+
+// queen retourne un jeu de données d'un artiste, dans le format de l'API
+func queen() *models.APIData {
+	return &models.APIData{
+		Artists:   []models.Artist{{ID: 1, Name: "Queen", FirstAlbum: "14-12-1973", Members: []string{"Freddie Mercury", "Brian May"}}},
+		Locations: []models.Location{{ID: 1, Locations: []string{"osaka-japan", "london-uk", "paris-france"}}},
+		Dates:     []models.Date{{ID: 1, Dates: []string{"*28-01-2020", "*10-02-2020", "11-02-2020", "*07-02-2020"}}},
+		Relations: []models.Relation{{ID: 1, DatesLocations: map[string][]string{
+			"osaka-japan":  {"28-01-2020"},
+			"london-uk":    {"10-02-2020", "11-02-2020"},
+			"paris-france": {"07-02-2020"},
+		}}},
+	}
+}
+
+// parse décode des surcharges JSON ou échoue
+func parse(t *testing.T, content string) *Overrides {
+	t.Helper()
+	o, err := Parse([]byte(content), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestApplyArtist(t *testing.T) {
+	tests := []struct {
+		name        string
+		overrides   string
+		wantName    string
+		wantMembers []string
+		wantFields  []string
+	}{
+		{
+			name:        "champs",
+			overrides:   `{"artists": {"1": {"name": "Queen + Adam Lambert", "firstAlbum": "13-07-1973"}}}`,
+			wantName:    "Queen + Adam Lambert",
+			wantMembers: []string{"Freddie Mercury", "Brian May"},
+			wantFields:  []string{FieldFirstAlbum, FieldName},
+		},
+		{
+			name:        "membres ajoutés et retirés",
+			overrides:   `{"artists": {"1": {"addMembers": ["Roger Taylor"], "removeMembers": ["Freddie Mercury"]}}}`,
+			wantName:    "Queen",
+			wantMembers: []string{"Brian May", "Roger Taylor"},
+			wantFields:  []string{FieldMembers},
+		},
+		{
+			name:        "membres remplacés",
+			overrides:   `{"artists": {"1": {"members": ["John Deacon"]}}}`,
+			wantName:    "Queen",
+			wantMembers: []string{"John Deacon"},
+			wantFields:  []string{FieldMembers},
+		},
+		{
+			name:        "valeurs identiques à l'API",
+			overrides:   `{"artists": {"1": {"name": "Queen", "addMembers": ["Brian May"], "removeMembers": ["John Deacon"]}}}`,
+			wantName:    "Queen",
+			wantMembers: []string{"Freddie Mercury", "Brian May"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := queen()
+			out, prov := parse(t, tt.overrides).Apply(data)
+
+			artist := out.Artists[0]
+			if artist.Name != tt.wantName || !reflect.DeepEqual(artist.Members, tt.wantMembers) {
+				t.Errorf("artiste = %q %v, attendu %q %v", artist.Name, artist.Members, tt.wantName, tt.wantMembers)
+			}
+			if got := prov.Fields(1); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("Fields(1) = %v, attendu %v", got, tt.wantFields)
+			}
+			if !reflect.DeepEqual(data, queen()) {
+				t.Errorf("Apply() a modifié les données d'origine")
+			}
+		})
+	}
+}
+
+func TestApplyConcerts(t *testing.T) {
+	tests := []struct {
+		name          string
+		overrides     string
+		wantRelation  map[string][]string
+		wantLocations []string
+		wantDates     []string
+		wantMarked    bool
+	}{
+		{
+			name:      "concert ajouté à un lieu connu",
+			overrides: `{"artists": {"1": {"addConcerts": {"london-uk": ["12-02-2020"]}}}}`,
+			wantRelation: map[string][]string{
+				"osaka-japan":  {"28-01-2020"},
+				"london-uk":    {"10-02-2020", "11-02-2020", "12-02-2020"},
+				"paris-france": {"07-02-2020"},
+			},
+			wantLocations: []string{"osaka-japan", "london-uk", "paris-france"},
+			wantDates:     []string{"*28-01-2020", "*10-02-2020", "11-02-2020", "*07-02-2020", "12-02-2020"},
+			wantMarked:    true,
+		},
+		{
+			name:      "nouveau lieu",
+			overrides: `{"artists": {"1": {"addConcerts": {"berlin-germany": ["01-03-2020", "02-03-2020"]}}}}`,
+			wantRelation: map[string][]string{
+				"osaka-japan":    {"28-01-2020"},
+				"london-uk":      {"10-02-2020", "11-02-2020"},
+				"paris-france":   {"07-02-2020"},
+				"berlin-germany": {"01-03-2020", "02-03-2020"},
+			},
+			wantLocations: []string{"osaka-japan", "london-uk", "paris-france", "berlin-germany"},
+			wantDates:     []string{"*28-01-2020", "*10-02-2020", "11-02-2020", "*07-02-2020", "*01-03-2020", "02-03-2020"},
+			wantMarked:    true,
+		},
+		{
+			name:      "concert retiré",
+			overrides: `{"artists": {"1": {"removeConcerts": {"london-uk": ["11-02-2020"]}}}}`,
+			wantRelation: map[string][]string{
+				"osaka-japan":  {"28-01-2020"},
+				"london-uk":    {"10-02-2020"},
+				"paris-france": {"07-02-2020"},
+			},
+			wantLocations: []string{"osaka-japan", "london-uk", "paris-france"},
+			wantDates:     []string{"*28-01-2020", "*10-02-2020", "*07-02-2020"},
+			wantMarked:    true,
+		},
+		{
+			name:      "lieu entier retiré",
+			overrides: `{"artists": {"1": {"removeConcerts": {"london-uk": []}}}}`,
+			wantRelation: map[string][]string{
+				"osaka-japan":  {"28-01-2020"},
+				"paris-france": {"07-02-2020"},
+			},
+			wantLocations: []string{"osaka-japan", "paris-france"},
+			wantDates:     []string{"*28-01-2020", "*07-02-2020"},
+			wantMarked:    true,
+		},
+		{
+			name:      "concerts déjà présents ou absents",
+			overrides: `{"artists": {"1": {"addConcerts": {"paris-france": ["07-02-2020"]}, "removeConcerts": {"rome-italy": []}}}}`,
+			wantRelation: map[string][]string{
+				"osaka-japan":  {"28-01-2020"},
+				"london-uk":    {"10-02-2020", "11-02-2020"},
+				"paris-france": {"07-02-2020"},
+			},
+			wantLocations: []string{"osaka-japan", "london-uk", "paris-france"},
+			wantDates:     []string{"*28-01-2020", "*10-02-2020", "11-02-2020", "*07-02-2020"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := queen()
+			o := parse(t, tt.overrides)
+			out, prov := o.Apply(data)
+
+			if got := out.Relations[0].DatesLocations; !reflect.DeepEqual(got, tt.wantRelation) {
+				t.Errorf("relation = %v, attendu %v", got, tt.wantRelation)
+			}
+			if got := out.Locations[0].Locations; !reflect.DeepEqual(got, tt.wantLocations) {
+				t.Errorf("lieux = %v, attendu %v", got, tt.wantLocations)
+			}
+			if got := out.Dates[0].Dates; !reflect.DeepEqual(got, tt.wantDates) {
+				t.Errorf("dates = %v, attendu %v", got, tt.wantDates)
+			}
+			if got := prov.Overridden(1, FieldConcerts); got != tt.wantMarked {
+				t.Errorf("Overridden(1, concerts) = %v, attendu %v", got, tt.wantMarked)
+			}
+			if !reflect.DeepEqual(data, queen()) {
+				t.Errorf("Apply() a modifié les données d'origine")
+			}
+
+			// ApplyRelation donne la même relation pour un seul artiste
+			relation := o.ApplyRelation(&queen().Relations[0])
+			if !reflect.DeepEqual(relation.DatesLocations, tt.wantRelation) {
+				t.Errorf("ApplyRelation() = %v, attendu %v", relation.DatesLocations, tt.wantRelation)
+			}
+		})
+	}
+}
+
+func TestApplyNewArtist(t *testing.T) {
+	o := parse(t, `{"artists": {
+		"7": {"name": "Groupe local", "members": ["Alice"], "addConcerts": {"lyon-france": ["05-05-2021"]}},
+		"8": {"firstAlbum": "01-01-2000"}
+	}}`)
+	out, prov := o.Apply(queen())
+
+	if len(out.Artists) != 2 || out.Artists[1].ID != 7 || out.Artists[1].Name != "Groupe local" {
+		t.Fatalf("artistes = %+v, attendu Queen et l'artiste local 7", out.Artists)
+	}
+	if !prov.Added(7) || prov.Added(1) || !prov.Overridden(7, FieldImage) {
+		t.Errorf("provenance: Added(7) = %v, Added(1) = %v, attendu true et false", prov.Added(7), prov.Added(1))
+	}
+	if len(prov.Warnings) != 1 {
+		t.Errorf("Warnings = %v, attendu un avertissement pour l'artiste 8 sans nom", prov.Warnings)
+	}
+	if got := prov.Artists(); got != 1 {
+		t.Errorf("Artists() = %d, attendu 1", got)
+	}
+
+	want := map[int][]string{7: {"*05-05-2021"}}
+	for _, date := range out.Dates {
+		if dates, ok := want[date.ID]; ok && !reflect.DeepEqual(date.Dates, dates) {
+			t.Errorf("dates de %d = %v, attendu %v", date.ID, date.Dates, dates)
+		}
+	}
+	for _, location := range out.Locations {
+		if location.ID == 7 && !reflect.DeepEqual(location.Locations, []string{"lyon-france"}) {
+			t.Errorf("lieux de 7 = %v, attendu [lyon-france]", location.Locations)
+		}
+	}
+	if len(out.Relations) != 2 || len(out.Locations) != 2 || len(out.Dates) != 2 {
+		t.Errorf("%d relations, %d lieux, %d dates, attendu 2 de chaque", len(out.Relations), len(out.Locations), len(out.Dates))
+	}
+}
+
+func TestProvenanceNil(t *testing.T) {
+	var prov *Provenance
+	if prov.Overridden(1, FieldName) || prov.Added(1) || prov.Fields(1) != nil || prov.Artists() != 0 {
+		t.Errorf("une provenance nil ne doit signaler aucune surcharge")
+	}
+}
@@ -0,0 +1,113 @@
+// Package overrides corrige localement les données de l'API : champs d'un artiste,
+// membres et concerts ajoutés ou retirés, artistes absents de l'API.
+//
+// Le fichier (JSON ou TOML) est indexé par ID d'artiste :
+//
+//	[artists.3]
+//	firstAlbum = "05-08-1967"
+//	addMembers = ["Bob Klose"]
+//
+//	[artists.3.removeConcerts]
+//	"london-uk" = []   # liste vide = tous les concerts du lieu
+package overrides
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ArtistOverride décrit les corrections d'un artiste. Un champ absent n'est pas modifié.
+type ArtistOverride struct {
+	Name         *string `json:"name,omitempty" toml:"name"`
+	Image        *string `json:"image,omitempty" toml:"image"`
+	CreationDate *int    `json:"creationDate,omitempty" toml:"creationDate"`
+	FirstAlbum   *string `json:"firstAlbum,omitempty" toml:"firstAlbum"`
+
+	// Members remplace la liste complète ; AddMembers et RemoveMembers l'ajustent
+	Members       []string `json:"members,omitempty" toml:"members"`
+	AddMembers    []string `json:"addMembers,omitempty" toml:"addMembers"`
+	RemoveMembers []string `json:"removeMembers,omitempty" toml:"removeMembers"`
+
+	// Concerts par lieu ; une liste vide dans RemoveConcerts retire le lieu entier
+	AddConcerts    map[string][]string `json:"addConcerts,omitempty" toml:"addConcerts"`
+	RemoveConcerts map[string][]string `json:"removeConcerts,omitempty" toml:"removeConcerts"`
+}
+
+// file est la structure du fichier de surcharges
+type file struct {
+	Artists map[string]ArtistOverride `json:"artists" toml:"artists"`
+}
+
+// Overrides contient les corrections de chaque artiste, par ID
+type Overrides struct {
+	path    string
+	artists map[int]ArtistOverride
+}
+
+// Load lit un fichier de surcharges (TOML si l'extension est .toml, JSON sinon)
+func Load(path string) (*Overrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la lecture des surcharges: %w", err)
+	}
+
+	o, err := Parse(data, strings.EqualFold(filepath.Ext(path), ".toml"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	o.path = path
+	return o, nil
+}
+
+// Parse décode des surcharges au format TOML ou JSON
+func Parse(data []byte, isTOML bool) (*Overrides, error) {
+	var f file
+	if isTOML {
+		meta, err := toml.NewDecoder(bytes.NewReader(data)).Decode(&f)
+		if err != nil {
+			return nil, fmt.Errorf("erreur lors du parsing des surcharges TOML: %w", err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("clé inconnue %q dans les surcharges TOML", undecoded[0].String())
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&f); err != nil {
+			return nil, fmt.Errorf("erreur lors du parsing des surcharges JSON: %w", err)
+		}
+	}
+
+	o := &Overrides{artists: make(map[int]ArtistOverride, len(f.Artists))}
+	for key, override := range f.Artists {
+		id, err := strconv.Atoi(key)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("ID d'artiste invalide %q dans les surcharges", key)
+		}
+		o.artists[id] = override
+	}
+	return o, nil
+}
+
+// Path retourne le chemin du fichier lu (vide si les surcharges viennent de Parse)
+func (o *Overrides) Path() string {
+	return o.path
+}
+
+// Len retourne le nombre d'artistes surchargés
+func (o *Overrides) Len() int {
+	return len(o.artists)
+}
+
+// Has indique si un artiste a des surcharges
+func (o *Overrides) Has(id int) bool {
+	_, ok := o.artists[id]
+	return ok
+}
@@ -0,0 +1,68 @@
+package overrides
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadJSONAndTOML(t *testing.T) {
+	files := map[string]string{
+		"overrides.json": `{"artists": {"3": {
+			"firstAlbum": "05-08-1967",
+			"addMembers": ["Bob Klose"],
+			"removeConcerts": {"london-uk": []}
+		}}}`,
+		"overrides.toml": `
+[artists.3]
+firstAlbum = "05-08-1967"
+addMembers = ["Bob Klose"]
+
+[artists.3.removeConcerts]
+"london-uk" = []
+`,
+	}
+
+	var loaded []*Overrides
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		o, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s): %v", name, err)
+		}
+		if o.Path() != path || o.Len() != 1 || !o.Has(3) {
+			t.Errorf("Load(%s) = %d artiste(s), Has(3) = %v", name, o.Len(), o.Has(3))
+		}
+		loaded = append(loaded, o)
+	}
+
+	// Les deux formats décrivent les mêmes surcharges
+	if !reflect.DeepEqual(loaded[0].artists, loaded[1].artists) {
+		t.Errorf("JSON et TOML différents:\n%+v\n%+v", loaded[0].artists, loaded[1].artists)
+	}
+}
+
+func TestParseRejectsInvalidOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		isTOML  bool
+	}{
+		{"json invalide", `{"artists": `, false},
+		{"champ json inconnu", `{"artists": {"1": {"nom": "Queen"}}}`, false},
+		{"clé toml inconnue", "[artists.1]\nnom = \"Queen\"\n", true},
+		{"ID non numérique", `{"artists": {"queen": {"name": "Queen"}}}`, false},
+		{"ID négatif", "[artists.-1]\nname = \"Queen\"\n", true},
+	}
+
+	for _, tt := range tests {
+		if _, err := Parse([]byte(tt.content), tt.isTOML); err == nil {
+			t.Errorf("%s: Parse() sans erreur, attendu une erreur", tt.name)
+		}
+	}
+}
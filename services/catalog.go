@@ -0,0 +1,198 @@
+package services
+
+import (
+	"fmt"
+	"groupie-tracker/geo"
+	"groupie-tracker/models"
+)
+
+// IssueKind identifie un problème de cohérence entre les endpoints
+type IssueKind int
+
+const (
+	// IssueDuplicateID : plusieurs éléments d'un même endpoint partagent un ID
+	IssueDuplicateID IssueKind = iota
+	// IssueMissingRelation : artiste sans relation (aucun concert connu)
+	IssueMissingRelation
+	// IssueOrphanRelation : relation sans artiste correspondant
+	IssueOrphanRelation
+	// IssueOrphanLocation : lieux sans artiste correspondant
+	IssueOrphanLocation
+	// IssueOrphanDate : dates sans artiste correspondant
+	IssueOrphanDate
+	// IssueMisaligned : l'élément n'est pas à la même position que son artiste
+	IssueMisaligned
+)
+
+// CatalogIssue décrit un orphelin ou un décalage détecté à la construction du catalogue
+type CatalogIssue struct {
+	Kind     IssueKind
+	Endpoint string
+	ID       int
+}
+
+// String retourne une description lisible du problème
+func (i CatalogIssue) String() string {
+	switch i.Kind {
+	case IssueDuplicateID:
+		return fmt.Sprintf("%s: ID %d en double", i.Endpoint, i.ID)
+	case IssueMissingRelation:
+		return fmt.Sprintf("artiste %d sans relation", i.ID)
+	case IssueOrphanRelation, IssueOrphanLocation, IssueOrphanDate:
+		return fmt.Sprintf("%s: ID %d sans artiste correspondant", i.Endpoint, i.ID)
+	case IssueMisaligned:
+		return fmt.Sprintf("%s: ID %d n'est pas à la position de son artiste", i.Endpoint, i.ID)
+	default:
+		return fmt.Sprintf("%s: problème inconnu pour l'ID %d", i.Endpoint, i.ID)
+	}
+}
+
+// CatalogEntry regroupe les données de tous les endpoints pour un artiste
+type CatalogEntry struct {
+	Artist   models.Artist
+	Relation *models.Relation
+	Location *models.Location
+	Date     *models.Date
+}
+
+// Catalog joint artistes, relations, lieux et dates par ID
+type Catalog struct {
+	entries []*CatalogEntry
+	byID    map[int]*CatalogEntry
+	issues  []CatalogIssue
+}
+
+// NewCatalog construit le catalogue et relève les orphelins et décalages
+func NewCatalog(data *models.APIData) *Catalog {
+	c := &Catalog{byID: make(map[int]*CatalogEntry)}
+	if data == nil {
+		return c
+	}
+
+	for i := range data.Artists {
+		artist := data.Artists[i]
+		if _, ok := c.byID[artist.ID]; ok {
+			c.addIssue(IssueDuplicateID, "artists", artist.ID)
+			continue
+		}
+		entry := &CatalogEntry{Artist: artist}
+		c.entries = append(c.entries, entry)
+		c.byID[artist.ID] = entry
+	}
+
+	for i := range data.Relations {
+		relation := &data.Relations[i]
+		entry, ok := c.byID[relation.ID]
+		switch {
+		case !ok:
+			c.addIssue(IssueOrphanRelation, "relation", relation.ID)
+		case entry.Relation != nil:
+			c.addIssue(IssueDuplicateID, "relation", relation.ID)
+		default:
+			entry.Relation = relation
+			c.checkAlignment(data, "relation", i, relation.ID)
+		}
+	}
+
+	for i := range data.Locations {
+		location := &data.Locations[i]
+		entry, ok := c.byID[location.ID]
+		switch {
+		case !ok:
+			c.addIssue(IssueOrphanLocation, "locations", location.ID)
+		case entry.Location != nil:
+			c.addIssue(IssueDuplicateID, "locations", location.ID)
+		default:
+			entry.Location = location
+			c.checkAlignment(data, "locations", i, location.ID)
+		}
+	}
+
+	for i := range data.Dates {
+		date := &data.Dates[i]
+		entry, ok := c.byID[date.ID]
+		switch {
+		case !ok:
+			c.addIssue(IssueOrphanDate, "dates", date.ID)
+		case entry.Date != nil:
+			c.addIssue(IssueDuplicateID, "dates", date.ID)
+		default:
+			entry.Date = date
+			c.checkAlignment(data, "dates", i, date.ID)
+		}
+	}
+
+	for _, entry := range c.entries {
+		if entry.Relation == nil {
+			c.addIssue(IssueMissingRelation, "relation", entry.Artist.ID)
+		}
+	}
+
+	return c
+}
+
+// addIssue enregistre un problème de cohérence
+func (c *Catalog) addIssue(kind IssueKind, endpoint string, id int) {
+	c.issues = append(c.issues, CatalogIssue{Kind: kind, Endpoint: endpoint, ID: id})
+}
+
+// checkAlignment signale un élément qui ne serait pas apparié correctement par position
+func (c *Catalog) checkAlignment(data *models.APIData, endpoint string, index, id int) {
+	if index >= len(data.Artists) || data.Artists[index].ID != id {
+		c.addIssue(IssueMisaligned, endpoint, id)
+	}
+}
+
+// Entries retourne les entrées dans l'ordre des artistes
+func (c *Catalog) Entries() []*CatalogEntry {
+	return c.entries
+}
+
+// Get retourne l'entrée d'un artiste par ID
+func (c *Catalog) Get(id int) (*CatalogEntry, bool) {
+	entry, ok := c.byID[id]
+	return entry, ok
+}
+
+// Issues retourne les orphelins et décalages relevés
+func (c *Catalog) Issues() []CatalogIssue {
+	return c.issues
+}
+
+// SetRelation remplace la relation d'un artiste.
+// Une relation manquante signalée à la construction n'est plus un problème.
+func (c *Catalog) SetRelation(relation *models.Relation) {
+	entry, ok := c.byID[relation.ID]
+	if !ok {
+		return
+	}
+	entry.Relation = relation
+
+	// Nouvelle tranche : celle retournée par Issues peut encore être lue
+	var issues []CatalogIssue
+	for _, issue := range c.issues {
+		if issue.Kind != IssueMissingRelation || issue.ID != relation.ID {
+			issues = append(issues, issue)
+		}
+	}
+	c.issues = issues
+}
+
+// Concerts retourne les concerts d'une entrée
+func (e *CatalogEntry) Concerts() []models.Concert {
+	if e.Relation == nil {
+		return nil
+	}
+
+	var concerts []models.Concert
+	for location, dates := range e.Relation.DatesLocations {
+		concerts = append(concerts, models.Concert{
+			ArtistID:   e.Artist.ID,
+			ArtistName: e.Artist.Name,
+			Location:   location,
+			Place:      geo.ParsePlace(location),
+			Dates:      dates,
+		})
+	}
+	return concerts
+}
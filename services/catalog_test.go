@@ -0,0 +1,99 @@
+package services
+
+import (
+	"groupie-tracker/models"
+	"reflect"
+	"testing"
+)
+
+func TestCatalogIssues(t *testing.T) {
+	artists := []models.Artist{{ID: 1, Name: "Queen"}, {ID: 2, Name: "SOJA"}}
+
+	tests := []struct {
+		name string
+		data *models.APIData
+		want []CatalogIssue
+	}{
+		{
+			name: "cohérent",
+			data: &models.APIData{
+				Artists:   artists,
+				Relations: []models.Relation{{ID: 1}, {ID: 2}},
+				Locations: []models.Location{{ID: 1}, {ID: 2}},
+				Dates:     []models.Date{{ID: 1}, {ID: 2}},
+			},
+		},
+		{
+			name: "orphelins",
+			data: &models.APIData{
+				Artists:   artists,
+				Relations: []models.Relation{{ID: 1}, {ID: 2}, {ID: 9}},
+				Locations: []models.Location{{ID: 1}, {ID: 2}, {ID: 8}},
+				Dates:     []models.Date{{ID: 1}, {ID: 2}, {ID: 7}},
+			},
+			want: []CatalogIssue{
+				{Kind: IssueOrphanRelation, Endpoint: "relation", ID: 9},
+				{Kind: IssueOrphanLocation, Endpoint: "locations", ID: 8},
+				{Kind: IssueOrphanDate, Endpoint: "dates", ID: 7},
+			},
+		},
+		{
+			name: "relation manquante",
+			data: &models.APIData{
+				Artists:   artists,
+				Relations: []models.Relation{{ID: 1}},
+			},
+			want: []CatalogIssue{{Kind: IssueMissingRelation, Endpoint: "relation", ID: 2}},
+		},
+		{
+			name: "doublons et décalage",
+			data: &models.APIData{
+				Artists:   []models.Artist{artists[0], artists[1], {ID: 1, Name: "Queen bis"}},
+				Relations: []models.Relation{{ID: 2}, {ID: 1}, {ID: 1}},
+			},
+			want: []CatalogIssue{
+				{Kind: IssueDuplicateID, Endpoint: "artists", ID: 1},
+				{Kind: IssueMisaligned, Endpoint: "relation", ID: 2},
+				{Kind: IssueMisaligned, Endpoint: "relation", ID: 1},
+				{Kind: IssueDuplicateID, Endpoint: "relation", ID: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog := NewCatalog(tt.data)
+			if got := catalog.Issues(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Issues() = %v, attendu %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogIssueString(t *testing.T) {
+	issue := CatalogIssue{Kind: IssueOrphanRelation, Endpoint: "relation", ID: 9}
+	if got, want := issue.String(), "relation: ID 9 sans artiste correspondant"; got != want {
+		t.Errorf("String() = %q, attendu %q", got, want)
+	}
+}
+
+func TestCatalogSetRelationClearsMissingRelation(t *testing.T) {
+	catalog := NewCatalog(&models.APIData{
+		Artists:   []models.Artist{{ID: 1, Name: "Queen"}, {ID: 2, Name: "SOJA"}, {ID: 3, Name: "Pink Floyd"}},
+		Relations: []models.Relation{{ID: 1}},
+	})
+	before := catalog.Issues()
+
+	catalog.SetRelation(&models.Relation{ID: 2, DatesLocations: map[string][]string{"paris-france": {"01-01-2020"}}})
+
+	want := []CatalogIssue{{Kind: IssueMissingRelation, Endpoint: "relation", ID: 3}}
+	if got := catalog.Issues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Issues() = %v, attendu %v", got, want)
+	}
+	if len(before) != 2 {
+		t.Errorf("Issues() lu avant SetRelation modifié: %v", before)
+	}
+	if entry, _ := catalog.Get(2); entry.Relation == nil || len(entry.Concerts()) != 1 {
+		t.Errorf("SetRelation() n'a pas remplacé la relation de l'artiste 2")
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"groupie-tracker/models"
+	"sort"
+	"strings"
+)
+
+// EventOrder est l'ordre de tri d'une liste de concerts
+type EventOrder int
+
+const (
+	ByDate   EventOrder = iota // Chronologique
+	ByArtist                   // Par nom d'artiste, puis chronologique
+	ByPlace                    // Par lieu, puis chronologique
+)
+
+// Events retourne tous les concerts (un par date), dans l'ordre demandé
+func (s *SearchService) Events(order EventOrder) []models.Event {
+	if s.data == nil {
+		return nil
+	}
+
+	var events []models.Event
+	for _, entry := range s.catalog.Entries() {
+		for _, concert := range entry.Concerts() {
+			events = append(events, concert.Events()...)
+		}
+	}
+	SortEvents(events, order)
+	return events
+}
+
+// EventsByArtistID retourne les concerts d'un artiste, triés chronologiquement
+func (s *SearchService) EventsByArtistID(artistID int) []models.Event {
+	var events []models.Event
+	for _, concert := range s.GetConcertsByArtistID(artistID) {
+		events = append(events, concert.Events()...)
+	}
+	models.SortEvents(events)
+	return events
+}
+
+// EventByID retrouve un concert à partir de son identifiant stable
+func (s *SearchService) EventByID(id string) (models.Event, bool) {
+	artistID, ok := models.ArtistIDFromEventID(id)
+	if !ok {
+		return models.Event{}, false
+	}
+
+	for _, event := range s.EventsByArtistID(artistID) {
+		if event.ID == id {
+			return event, true
+		}
+	}
+	return models.Event{}, false
+}
+
+// SortEvents trie des concerts dans l'ordre demandé
+func SortEvents(events []models.Event, order EventOrder) {
+	models.SortEvents(events)
+
+	switch order {
+	case ByArtist:
+		sort.SliceStable(events, func(i, j int) bool {
+			return strings.ToLower(events[i].ArtistName) < strings.ToLower(events[j].ArtistName)
+		})
+	case ByPlace:
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Place.String() < events[j].Place.String()
+		})
+	}
+}
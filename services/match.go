@@ -0,0 +1,116 @@
+package services
+
+import (
+	"groupie-tracker/fuzzy"
+	"groupie-tracker/models"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Scores de pertinence selon la qualité de la correspondance
+const (
+	scoreExact      = 1.0 // Le champ entier correspond
+	scorePrefix     = 0.9 // Le champ commence par la recherche
+	scoreWordPrefix = 0.8 // Un mot du champ commence par la recherche
+	scoreSubstring  = 0.6 // La recherche apparaît au milieu d'un mot
+	scoreFuzzy      = 0.5 // Correspondance à une faute près (moins 0.1 par faute supplémentaire)
+)
+
+// match est le résultat de la comparaison d'un champ avec une recherche
+type match struct {
+	score float64
+	spans []models.Span
+}
+
+// matchText cherche query dans text sans tenir compte de la casse.
+// Les plages retournées sont des positions en octets dans text.
+func matchText(text, query string) (match, bool) {
+	spans := indexFold(text, query)
+	if len(spans) == 0 {
+		return match{}, false
+	}
+
+	m := match{score: scoreSubstring, spans: spans}
+	for _, span := range spans {
+		switch {
+		case span.Start == 0 && span.End == len(text):
+			m.score = scoreExact
+		case span.Start == 0:
+			m.score = max(m.score, scorePrefix)
+		case isWordStart(text, span.Start):
+			m.score = max(m.score, scoreWordPrefix)
+		}
+	}
+	return m, true
+}
+
+// matchFuzzy cherche query dans text en tolérant des fautes de frappe.
+// Le score reste inférieur à celui de toute correspondance exacte.
+func matchFuzzy(text, query string, tol fuzzy.Tolerance) (match, bool) {
+	result, ok := fuzzy.Match(text, query, tol)
+	if !ok {
+		return match{}, false
+	}
+	score := max(scoreFuzzy-0.1*float64(result.Distance-1), 0.1)
+	return match{score: score, spans: []models.Span{{Start: result.Start, End: result.End}}}, true
+}
+
+// indexFold retourne les occurrences de query dans text (sans recouvrement), sans tenir compte de la casse
+func indexFold(text, query string) []models.Span {
+	n := utf8.RuneCountInString(query)
+	if n == 0 {
+		return nil
+	}
+
+	var spans []models.Span
+	for start := 0; start < len(text); {
+		end := start
+		for i := 0; i < n && end < len(text); i++ {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			end += size
+		}
+		if strings.EqualFold(text[start:end], query) {
+			spans = append(spans, models.Span{Start: start, End: end})
+			start = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		start += size
+	}
+	return spans
+}
+
+// isWordStart indique si la position i de text est le début d'un mot
+func isWordStart(text string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// shiftSpans décale des plages de offset octets (champ affiché après un préfixe)
+func shiftSpans(spans []models.Span, offset int) []models.Span {
+	shifted := make([]models.Span, len(spans))
+	for i, span := range spans {
+		shifted[i] = models.Span{Start: span.Start + offset, End: span.End + offset}
+	}
+	return shifted
+}
+
+// rankResults trie les résultats par pertinence décroissante,
+// puis par type (artistes d'abord) et par texte pour un ordre stable
+func rankResults(results []models.SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Value < b.Value
+	})
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"groupie-tracker/models"
+	"reflect"
+	"testing"
+)
+
+func TestMatchText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		query string
+		want  bool
+		score float64
+		spans []models.Span
+	}{
+		{"exacte", "Queen", "queen", true, scoreExact, []models.Span{{Start: 0, End: 5}}},
+		{"préfixe", "Queen", "qu", true, scorePrefix, []models.Span{{Start: 0, End: 2}}},
+		{"début de mot", "Pink Floyd", "flo", true, scoreWordPrefix, []models.Span{{Start: 5, End: 8}}},
+		{"milieu de mot", "Pink Floyd", "loy", true, scoreSubstring, []models.Span{{Start: 6, End: 9}}},
+		{"plusieurs occurrences", "Mac Miller", "m", true, scorePrefix, []models.Span{{Start: 0, End: 1}, {Start: 4, End: 5}}},
+		{"sans recouvrement", "aaaa", "aa", true, scorePrefix, []models.Span{{Start: 0, End: 2}, {Start: 2, End: 4}}},
+		{"après un tiret", "Jay-Z", "z", true, scoreWordPrefix, []models.Span{{Start: 4, End: 5}}},
+		{"positions en octets", "Mötley Crüe", "crüe", true, scoreWordPrefix, []models.Span{{Start: 8, End: 13}}},
+		{"casse ignorée sur les accents", "MÖTLEY", "mötley", true, scoreExact, []models.Span{{Start: 0, End: 7}}},
+		{"absente", "Queen", "floyd", false, 0, nil},
+		{"vide", "Queen", "", false, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := matchText(tt.text, tt.query)
+			if ok != tt.want {
+				t.Fatalf("matchText(%q, %q) trouvé = %v, attendu %v", tt.text, tt.query, ok, tt.want)
+			}
+			if got.score != tt.score {
+				t.Errorf("matchText(%q, %q) score = %v, attendu %v", tt.text, tt.query, got.score, tt.score)
+			}
+			if !reflect.DeepEqual(got.spans, tt.spans) {
+				t.Errorf("matchText(%q, %q) plages = %v, attendu %v", tt.text, tt.query, got.spans, tt.spans)
+			}
+		})
+	}
+}
+
+func TestRankResults(t *testing.T) {
+	results := []models.SearchResult{
+		{Kind: models.KindLocation, Value: "b", Score: scoreSubstring},
+		{Kind: models.KindMember, Value: "a", Score: scoreFuzzy},
+		{Kind: models.KindArtist, Value: "b", Score: scoreSubstring},
+		{Kind: models.KindArtist, Value: "a", Score: scoreSubstring},
+		{Kind: models.KindArtist, Value: "z", Score: scoreExact},
+	}
+	rankResults(results)
+
+	want := []string{"artist z", "artist a", "artist b", "location b", "member a"}
+	for i, r := range results {
+		if got := r.Kind.String() + " " + r.Value; got != want[i] {
+			t.Errorf("rankResults()[%d] = %q, attendu %q", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"groupie-tracker/models"
+	"sort"
+	"strings"
+)
+
+// MembershipIndex relie chaque musicien (identité normalisée) aux groupes dont il a fait partie
+type MembershipIndex struct {
+	members  map[string]*models.Member
+	byArtist map[int][]string // ID d'artiste -> identités de ses membres, dans l'ordre de l'API
+}
+
+// NewMembershipIndex construit l'index des membres à partir du catalogue
+func NewMembershipIndex(catalog *Catalog) *MembershipIndex {
+	idx := &MembershipIndex{
+		members:  make(map[string]*models.Member),
+		byArtist: make(map[int][]string),
+	}
+
+	for _, entry := range catalog.Entries() {
+		artist := entry.Artist
+		for _, name := range artist.Members {
+			id := models.MemberID(name)
+			if id == "" {
+				continue
+			}
+
+			member, ok := idx.members[id]
+			if !ok {
+				member = &models.Member{ID: id, Name: strings.Join(strings.Fields(name), " ")}
+				idx.members[id] = member
+			}
+			// Un même nom répété dans un groupe ne compte qu'une fois
+			if n := len(member.ArtistIDs); n > 0 && member.ArtistIDs[n-1] == artist.ID {
+				continue
+			}
+			member.ArtistIDs = append(member.ArtistIDs, artist.ID)
+			idx.byArtist[artist.ID] = append(idx.byArtist[artist.ID], id)
+		}
+	}
+
+	for _, member := range idx.members {
+		sort.Ints(member.ArtistIDs)
+	}
+
+	return idx
+}
+
+// Members retourne tous les membres, triés par nom
+func (idx *MembershipIndex) Members() []models.Member {
+	members := make([]models.Member, 0, len(idx.members))
+	for _, member := range idx.members {
+		members = append(members, *member)
+	}
+	sortMembers(members)
+	return members
+}
+
+// Member retourne un membre à partir de son nom (quelle que soit sa graphie)
+func (idx *MembershipIndex) Member(name string) (models.Member, bool) {
+	member, ok := idx.members[models.MemberID(name)]
+	if !ok {
+		return models.Member{}, false
+	}
+	return *member, true
+}
+
+// MembersOf retourne les membres d'un artiste, dans l'ordre de l'API
+func (idx *MembershipIndex) MembersOf(artistID int) []models.Member {
+	var members []models.Member
+	for _, id := range idx.byArtist[artistID] {
+		members = append(members, *idx.members[id])
+	}
+	return members
+}
+
+// MultiBand retourne les membres ayant fait partie de plusieurs groupes, triés par nom
+func (idx *MembershipIndex) MultiBand() []models.Member {
+	var members []models.Member
+	for _, member := range idx.members {
+		if member.Bands() > 1 {
+			members = append(members, *member)
+		}
+	}
+	sortMembers(members)
+	return members
+}
+
+// sortMembers trie des membres par nom puis par identité
+func sortMembers(members []models.Member) {
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Name != members[j].Name {
+			return members[i].Name < members[j].Name
+		}
+		return members[i].ID < members[j].ID
+	})
+}
+
+// Memberships retourne l'index des membres
+func (s *SearchService) Memberships() *MembershipIndex {
+	return s.members
+}
+
+// BandsOf retourne tous les groupes dont une personne a fait partie
+func (s *SearchService) BandsOf(memberName string) []models.Artist {
+	if s.data == nil {
+		return nil
+	}
+
+	member, ok := s.members.Member(memberName)
+	if !ok {
+		return nil
+	}
+
+	var results []models.Artist
+	for _, id := range member.ArtistIDs {
+		if entry, ok := s.catalog.Get(id); ok {
+			results = append(results, entry.Artist)
+		}
+	}
+	return results
+}
+
+// SharedMembers retourne les membres communs à deux artistes
+func (s *SearchService) SharedMembers(artistA, artistB int) []models.Member {
+	if s.data == nil || artistA == artistB {
+		return nil
+	}
+
+	inB := make(map[string]bool)
+	for _, id := range s.members.byArtist[artistB] {
+		inB[id] = true
+	}
+
+	var shared []models.Member
+	for _, member := range s.members.MembersOf(artistA) {
+		if inB[member.ID] {
+			shared = append(shared, member)
+		}
+	}
+	return shared
+}
@@ -2,21 +2,43 @@ package services
 
 import (
 	"fmt"
+	"groupie-tracker/fuzzy"
+	"groupie-tracker/geo"
 	"groupie-tracker/models"
+	"sort"
 	"strings"
 )
 
 // SearchService gère toutes les recherches
 type SearchService struct {
-	data *models.APIData
+	data      *models.APIData
+	catalog   *Catalog
+	members   *MembershipIndex
+	tolerance fuzzy.Tolerance
 }
 
 // NewSearchService crée un nouveau service de recherche
 func NewSearchService(data *models.APIData) *SearchService {
-	return &SearchService{data: data}
+	catalog := NewCatalog(data)
+	return &SearchService{
+		data:      data,
+		catalog:   catalog,
+		members:   NewMembershipIndex(catalog),
+		tolerance: fuzzy.DefaultTolerance,
+	}
+}
+
+// SetTolerance règle la tolérance aux fautes de frappe (fuzzy.Tolerance{} la désactive)
+func (s *SearchService) SetTolerance(tolerance fuzzy.Tolerance) {
+	s.tolerance = tolerance
 }
 
-// SearchArtists recherche des artistes par nom
+// Catalog retourne le catalogue des données jointes par ID
+func (s *SearchService) Catalog() *Catalog {
+	return s.catalog
+}
+
+// SearchArtists recherche des artistes par nom, en tolérant les fautes de frappe
 func (s *SearchService) SearchArtists(query string) []models.Artist {
 	if s.data == nil {
 		return nil
@@ -24,16 +46,38 @@ func (s *SearchService) SearchArtists(query string) []models.Artist {
 
 	query = strings.ToLower(strings.TrimSpace(query))
 	if query == "" {
-		return s.data.Artists
+		return s.allArtists()
 	}
 
+	// Les correspondances exactes d'abord, puis les approchées de la plus proche à la plus lointaine
 	var results []models.Artist
-	for _, artist := range s.data.Artists {
+	var approx []models.Artist
+	var distances []int
+	for _, entry := range s.catalog.Entries() {
+		artist := entry.Artist
 		if strings.Contains(strings.ToLower(artist.Name), query) {
 			results = append(results, artist)
+		} else if result, ok := fuzzy.Match(artist.Name, query, s.tolerance); ok {
+			approx = append(approx, artist)
+			distances = append(distances, result.Distance)
 		}
 	}
-	return results
+
+	sort.Stable(byDistance{artists: approx, distances: distances})
+	return append(results, approx...)
+}
+
+// byDistance trie des artistes par nombre de fautes croissant
+type byDistance struct {
+	artists   []models.Artist
+	distances []int
+}
+
+func (b byDistance) Len() int           { return len(b.artists) }
+func (b byDistance) Less(i, j int) bool { return b.distances[i] < b.distances[j] }
+func (b byDistance) Swap(i, j int) {
+	b.artists[i], b.artists[j] = b.artists[j], b.artists[i]
+	b.distances[i], b.distances[j] = b.distances[j], b.distances[i]
 }
 
 // SearchByMember recherche des artistes par membre
@@ -48,7 +92,8 @@ func (s *SearchService) SearchByMember(memberName string) []models.Artist {
 	}
 
 	var results []models.Artist
-	for _, artist := range s.data.Artists {
+	for _, entry := range s.catalog.Entries() {
+		artist := entry.Artist
 		for _, member := range artist.Members {
 			if strings.Contains(strings.ToLower(member), memberName) {
 				results = append(results, artist)
@@ -71,18 +116,10 @@ func (s *SearchService) SearchByLocation(location string) []models.Concert {
 	}
 
 	var concerts []models.Concert
-	for i, artist := range s.data.Artists {
-		if i < len(s.data.Relations) {
-			relation := s.data.Relations[i]
-			for loc, dates := range relation.DatesLocations {
-				if strings.Contains(strings.ToLower(loc), location) {
-					concerts = append(concerts, models.Concert{
-						ArtistID:   artist.ID,
-						ArtistName: artist.Name,
-						Location:   loc,
-						Dates:      dates,
-					})
-				}
+	for _, entry := range s.catalog.Entries() {
+		for _, concert := range entry.Concerts() {
+			if placeMatches(concert.Place, location) {
+				concerts = append(concerts, concert)
 			}
 		}
 	}
@@ -101,7 +138,8 @@ func (s *SearchService) SearchByAlbumDate(date string) []models.Artist {
 	}
 
 	var results []models.Artist
-	for _, artist := range s.data.Artists {
+	for _, entry := range s.catalog.Entries() {
+		artist := entry.Artist
 		if strings.Contains(artist.FirstAlbum, date) {
 			results = append(results, artist)
 		}
@@ -116,7 +154,8 @@ func (s *SearchService) SearchByCreationDate(year int) []models.Artist {
 	}
 
 	var results []models.Artist
-	for _, artist := range s.data.Artists {
+	for _, entry := range s.catalog.Entries() {
+		artist := entry.Artist
 		if artist.CreationDate == year {
 			results = append(results, artist)
 		}
@@ -124,7 +163,9 @@ func (s *SearchService) SearchByCreationDate(year int) []models.Artist {
 	return results
 }
 
-// UniversalSearch effectue une recherche globale
+// UniversalSearch effectue une recherche globale, tolérante aux fautes de frappe.
+// Les résultats sont triés par pertinence (les correspondances approchées après les exactes)
+// et indiquent les plages du texte qui correspondent.
 func (s *SearchService) UniversalSearch(query string) []models.SearchResult {
 	if s.data == nil {
 		return nil
@@ -138,69 +179,86 @@ func (s *SearchService) UniversalSearch(query string) []models.SearchResult {
 	var results []models.SearchResult
 	seen := make(map[string]bool)
 
+	add := func(key string, result models.SearchResult) {
+		if !seen[key] {
+			results = append(results, result)
+			seen[key] = true
+		}
+	}
+
 	// Recherche d'artistes
-	for _, artist := range s.data.Artists {
-		if strings.Contains(strings.ToLower(artist.Name), query) {
-			key := fmt.Sprintf("artist-%s", artist.Name)
-			if !seen[key] {
-				results = append(results, models.SearchResult{
-					Type:   "artist",
-					Value:  artist.Name,
-					Artist: &artist,
-				})
-				seen[key] = true
-			}
+	for _, entry := range s.catalog.Entries() {
+		artist := &entry.Artist
+		if m, ok := s.matchField(artist.Name, query); ok {
+			add(fmt.Sprintf("artist-%s", artist.Name), models.SearchResult{
+				Kind:    models.KindArtist,
+				Value:   artist.Name,
+				Field:   "name",
+				Matches: m.spans,
+				Score:   m.score,
+				Artist:  artist,
+			})
 		}
 
 		// Recherche de membres
 		for _, member := range artist.Members {
-			if strings.Contains(strings.ToLower(member), query) {
-				key := fmt.Sprintf("member-%s-%s", member, artist.Name)
-				if !seen[key] {
-					results = append(results, models.SearchResult{
-						Type:   "member",
-						Value:  fmt.Sprintf("%s (membre de %s)", member, artist.Name),
-						Artist: &artist,
-					})
-					seen[key] = true
-				}
+			if m, ok := s.matchField(member, query); ok {
+				add(fmt.Sprintf("member-%s-%s", member, artist.Name), models.SearchResult{
+					Kind:    models.KindMember,
+					Value:   fmt.Sprintf("%s (membre de %s)", member, artist.Name),
+					Field:   "members",
+					Matches: m.spans,
+					Score:   m.score,
+					Artist:  artist,
+				})
 			}
 		}
 
 		// Recherche par date d'album
-		if strings.Contains(strings.ToLower(artist.FirstAlbum), query) {
-			key := fmt.Sprintf("album-%s", artist.Name)
-			if !seen[key] {
-				results = append(results, models.SearchResult{
-					Type:   "album",
-					Value:  fmt.Sprintf("%s - Premier album: %s", artist.Name, artist.FirstAlbum),
-					Artist: &artist,
-				})
-				seen[key] = true
-			}
+		if m, ok := matchText(artist.FirstAlbum, query); ok {
+			prefix := fmt.Sprintf("%s - Premier album: ", artist.Name)
+			add(fmt.Sprintf("album-%s", artist.Name), models.SearchResult{
+				Kind:    models.KindAlbum,
+				Value:   prefix + artist.FirstAlbum,
+				Field:   "firstAlbum",
+				Matches: shiftSpans(m.spans, len(prefix)),
+				Score:   m.score,
+				Artist:  artist,
+			})
 		}
 	}
 
 	// Recherche de lieux
-	for i, artist := range s.data.Artists {
-		if i < len(s.data.Relations) {
-			relation := s.data.Relations[i]
-			for location := range relation.DatesLocations {
-				if strings.Contains(strings.ToLower(location), query) {
-					key := fmt.Sprintf("location-%s-%s", location, artist.Name)
-					if !seen[key] {
-						results = append(results, models.SearchResult{
-							Type:   "location",
-							Value:  fmt.Sprintf("%s - Concert à %s", artist.Name, FormatLocation(location)),
-							Artist: &artist,
-						})
-						seen[key] = true
-					}
-				}
+	for _, entry := range s.catalog.Entries() {
+		if entry.Relation == nil {
+			continue
+		}
+		artist := &entry.Artist
+		for location := range entry.Relation.DatesLocations {
+			place := geo.ParsePlace(location)
+			m, ok := s.matchField(place.String(), query)
+			if !ok && !placeMatches(place, query) {
+				continue
+			}
+
+			prefix := fmt.Sprintf("%s - Concert à ", artist.Name)
+			result := models.SearchResult{
+				Kind:   models.KindLocation,
+				Value:  prefix + place.String(),
+				Field:  "locations",
+				Score:  scoreSubstring,
+				Artist: artist,
+			}
+			// Correspondance sur la clé ou le code pays seulement : rien à surligner
+			if ok {
+				result.Matches = shiftSpans(m.spans, len(prefix))
+				result.Score = m.score
 			}
+			add(fmt.Sprintf("location-%s-%s", location, artist.Name), result)
 		}
 	}
 
+	rankResults(results)
 	return results
 }
 
@@ -211,7 +269,8 @@ func (s *SearchService) FilterByMemberCount(min, max int) []models.Artist {
 	}
 
 	var results []models.Artist
-	for _, artist := range s.data.Artists {
+	for _, entry := range s.catalog.Entries() {
+		artist := entry.Artist
 		count := len(artist.Members)
 		if count >= min && count <= max {
 			results = append(results, artist)
@@ -227,7 +286,8 @@ func (s *SearchService) FilterByCreationYear(minYear, maxYear int) []models.Arti
 	}
 
 	var results []models.Artist
-	for _, artist := range s.data.Artists {
+	for _, entry := range s.catalog.Entries() {
+		artist := entry.Artist
 		if artist.CreationDate >= minYear && artist.CreationDate <= maxYear {
 			results = append(results, artist)
 		}
@@ -241,30 +301,74 @@ func (s *SearchService) GetConcertsByArtistID(artistID int) []models.Concert {
 		return nil
 	}
 
-	var concerts []models.Concert
-	for i, artist := range s.data.Artists {
-		if artist.ID == artistID && i < len(s.data.Relations) {
-			relation := s.data.Relations[i]
-			for location, dates := range relation.DatesLocations {
-				concerts = append(concerts, models.Concert{
-					ArtistID:   artist.ID,
-					ArtistName: artist.Name,
-					Location:   location,
-					Dates:      dates,
-				})
-			}
+	entry, ok := s.catalog.Get(artistID)
+	if !ok {
+		return nil
+	}
+	return entry.Concerts()
+}
+
+// UpdateRelation remplace la relation d'un artiste après un rafraîchissement individuel.
+// Les données partagées (jeu de données du rechargement périodique) ne sont pas modifiées :
+// le service garde sa propre copie des relations. À appeler depuis le thread de l'interface.
+func (s *SearchService) UpdateRelation(relation models.Relation) {
+	if s.data == nil {
+		return
+	}
+
+	relations := make([]models.Relation, 0, len(s.data.Relations)+1)
+	replaced := false
+	for _, existing := range s.data.Relations {
+		if existing.ID == relation.ID {
+			existing, replaced = relation, true
 		}
+		relations = append(relations, existing)
 	}
-	return concerts
+	if !replaced {
+		relations = append(relations, relation)
+	}
+
+	data := *s.data
+	data.Relations = relations
+	s.data = &data
+
+	updated := relation
+	s.catalog.SetRelation(&updated)
+}
+
+// matchField compare un champ à la recherche, exactement puis en tolérant des fautes de frappe
+func (s *SearchService) matchField(text, query string) (match, bool) {
+	if m, ok := matchText(text, query); ok {
+		return m, true
+	}
+	return matchFuzzy(text, query, s.tolerance)
+}
+
+// allArtists retourne tous les artistes du catalogue
+func (s *SearchService) allArtists() []models.Artist {
+	artists := make([]models.Artist, 0, len(s.catalog.Entries()))
+	for _, entry := range s.catalog.Entries() {
+		artists = append(artists, entry.Artist)
+	}
+	return artists
 }
 
-// FormatLocation formate un nom de lieu
+// FormatLocation formate un nom de lieu ("north_carolina-usa" -> "North Carolina, États-Unis")
 func FormatLocation(location string) string {
-	location = strings.ReplaceAll(location, "-", ", ")
-	location = strings.ReplaceAll(location, "_", " ")
-	parts := strings.Split(location, ", ")
-	for i, part := range parts {
-		parts[i] = strings.Title(strings.ToLower(part))
+	return geo.ParsePlace(location).String()
+}
+
+// placeMatches indique si un lieu correspond à une recherche (en minuscules) :
+// clé de l'API, ville, région, nom du pays ou code ISO
+func placeMatches(place models.Place, query string) bool {
+	if strings.Contains(strings.ToLower(place.Key), query) ||
+		strings.Contains(strings.ToLower(place.Key), strings.ReplaceAll(query, " ", "_")) {
+		return true
+	}
+	for _, field := range []string{place.City, place.Region, place.Country} {
+		if field != "" && strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
 	}
-	return strings.Join(parts, ", ")
+	return place.CountryCode != "" && strings.EqualFold(place.CountryCode, query)
 }
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"groupie-tracker/fakeapi"
+	"groupie-tracker/fuzzy"
+	"groupie-tracker/models"
+	"testing"
+)
+
+func TestSearchArtists(t *testing.T) {
+	service := NewSearchService(fakeapi.Fixtures())
+
+	tests := []struct {
+		query string
+		want  string // premier résultat attendu ("" : aucun résultat)
+	}{
+		{"queen", "Queen"},
+		{"quen", "Queen"},
+		{"pink floid", "Pink Floyd"},
+		{"pinc flo", "Pink Floyd"},
+		{"scorpion", "Scorpions"},
+		{"skorpions", "Scorpions"},
+		{"zzzz", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results := service.SearchArtists(tt.query)
+			if tt.want == "" {
+				if len(results) > 0 {
+					t.Fatalf("SearchArtists(%q) = %v, attendu aucun résultat", tt.query, results[0].Name)
+				}
+				return
+			}
+			if len(results) == 0 || results[0].Name != tt.want {
+				t.Fatalf("SearchArtists(%q) = %v, attendu %s en premier", tt.query, artistNames(results), tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchArtistsExactFirst(t *testing.T) {
+	data := &models.APIData{Artists: []models.Artist{
+		{ID: 1, Name: "Qeen"},
+		{ID: 2, Name: "Queens of the Stone Age"},
+	}}
+	service := NewSearchService(data)
+
+	got := artistNames(service.SearchArtists("queen"))
+	want := []string{"Queens of the Stone Age", "Qeen"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("SearchArtists(\"queen\") = %v, attendu %v", got, want)
+	}
+
+	service.SetTolerance(fuzzy.Tolerance{})
+	if got := artistNames(service.SearchArtists("quen")); len(got) != 0 {
+		t.Errorf("SearchArtists(\"quen\") sans tolérance = %v, attendu aucun résultat", got)
+	}
+}
+
+func TestUniversalSearch(t *testing.T) {
+	service := NewSearchService(fakeapi.Fixtures())
+
+	tests := []struct {
+		query   string
+		kind    models.ResultKind
+		value   string
+		passage string // partie surlignée du premier résultat
+	}{
+		{"quen", models.KindArtist, "Queen", "Queen"},
+		{"pink floid", models.KindArtist, "Pink Floyd", "Pink Floyd"},
+		{"mercury", models.KindMember, "Freddie Mercury (membre de Queen)", "Mercury"},
+		{"freddie mercuri", models.KindMember, "Freddie Mercury (membre de Queen)", "Freddie Mercury"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results := service.UniversalSearch(tt.query)
+			if len(results) == 0 {
+				t.Fatalf("UniversalSearch(%q) sans résultat", tt.query)
+			}
+			first := results[0]
+			if first.Kind != tt.kind || first.Value != tt.value {
+				t.Fatalf("UniversalSearch(%q)[0] = %v %q, attendu %v %q", tt.query, first.Kind, first.Value, tt.kind, tt.value)
+			}
+			if len(first.Matches) == 0 {
+				t.Fatalf("UniversalSearch(%q)[0] sans plage surlignée", tt.query)
+			}
+			span := first.Matches[0]
+			if got := first.Value[span.Start:span.End]; got != tt.passage {
+				t.Errorf("UniversalSearch(%q)[0] surligne %q, attendu %q", tt.query, got, tt.passage)
+			}
+			for i := 1; i < len(results); i++ {
+				if results[i].Score > results[i-1].Score {
+					t.Errorf("UniversalSearch(%q) non trié: %v après %v", tt.query, results[i], results[i-1])
+				}
+			}
+		})
+	}
+}
+
+// artistNames retourne les noms des artistes
+func artistNames(artists []models.Artist) []string {
+	names := make([]string, len(artists))
+	for i, artist := range artists {
+		names[i] = artist.Name
+	}
+	return names
+}
+
+func TestUpdateRelation(t *testing.T) {
+	data := fakeapi.Fixtures()
+	shared := data.Relations
+	before := len(shared[0].DatesLocations)
+	service := NewSearchService(data)
+
+	updated := models.Relation{ID: shared[0].ID, DatesLocations: map[string][]string{"paris-france": {"01-01-2030"}}}
+	service.UpdateRelation(updated)
+
+	// Le jeu de données partagé (comparé par le rechargement périodique) reste intact
+	if len(data.Relations[0].DatesLocations) != before {
+		t.Errorf("UpdateRelation() a modifié les relations partagées")
+	}
+
+	concerts := service.GetConcertsByArtistID(updated.ID)
+	if len(concerts) != 1 || concerts[0].Location != "paris-france" {
+		t.Errorf("GetConcertsByArtistID() après mise à jour = %+v", concerts)
+	}
+
+	added := models.Relation{ID: 999, DatesLocations: map[string][]string{"lyon-france": {"01-01-2030"}}}
+	service.UpdateRelation(added)
+	if len(data.Relations) != len(shared) {
+		t.Errorf("UpdateRelation() a ajouté une relation au jeu de données partagé")
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import "sort"
+
+// CountryCount est le nombre de concerts dans un pays
+type CountryCount struct {
+	Code     string
+	Name     string
+	Concerts int
+}
+
+// ConcertStats regroupe les statistiques globales des concerts
+type ConcertStats struct {
+	Artists   int
+	Concerts  int
+	Places    int
+	Countries []CountryCount // triés par nombre de concerts décroissant
+}
+
+// TopCountries retourne les n pays avec le plus de concerts
+func (s ConcertStats) TopCountries(n int) []CountryCount {
+	if len(s.Countries) > n {
+		return s.Countries[:n]
+	}
+	return s.Countries
+}
+
+// Stats calcule les statistiques des concerts à partir des lieux structurés
+func (s *SearchService) Stats() ConcertStats {
+	var stats ConcertStats
+	if s.data == nil {
+		return stats
+	}
+
+	places := make(map[string]bool)
+	countries := make(map[string]*CountryCount)
+
+	for _, entry := range s.catalog.Entries() {
+		stats.Artists++
+		for _, concert := range entry.Concerts() {
+			stats.Concerts += len(concert.Dates)
+			places[concert.Location] = true
+
+			// Les pays inconnus de la table sont regroupés par nom
+			key := concert.Place.CountryCode
+			if key == "" {
+				key = concert.Place.Country
+			}
+			count, ok := countries[key]
+			if !ok {
+				count = &CountryCount{Code: concert.Place.CountryCode, Name: concert.Place.Country}
+				countries[key] = count
+			}
+			count.Concerts += len(concert.Dates)
+		}
+	}
+
+	stats.Places = len(places)
+	for _, count := range countries {
+		stats.Countries = append(stats.Countries, *count)
+	}
+	sort.Slice(stats.Countries, func(i, j int) bool {
+		if stats.Countries[i].Concerts != stats.Countries[j].Concerts {
+			return stats.Countries[i].Concerts > stats.Countries[j].Concerts
+		}
+		return stats.Countries[i].Name < stats.Countries[j].Name
+	})
+
+	return stats
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Severity indique la gravité d'un problème de données
+type Severity int
+
+const (
+	// SeverityWarning : donnée suspecte mais utilisable
+	SeverityWarning Severity = iota
+	// SeverityError : donnée inutilisable ou incohérente
+	SeverityError
+)
+
+// String retourne le nom français de la gravité
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "erreur"
+	}
+	return "avertissement"
+}
+
+// Finding décrit un problème relevé sur une entité
+type Finding struct {
+	Severity Severity
+	Entity   string // "artist", "location", "date", "relation"
+	ID       int
+	Field    string
+	Message  string
+}
+
+// String retourne une ligne lisible ("❌ artist 3 (firstAlbum): ...")
+func (f Finding) String() string {
+	icon := "⚠️"
+	if f.Severity == SeverityError {
+		icon = "❌"
+	}
+	if f.Field == "" {
+		return fmt.Sprintf("%s %s %d: %s", icon, f.Entity, f.ID, f.Message)
+	}
+	return fmt.Sprintf("%s %s %d (%s): %s", icon, f.Entity, f.ID, f.Field, f.Message)
+}
+
+// ValidationReport regroupe les problèmes relevés sur un jeu de données
+type ValidationReport struct {
+	Artists  int
+	Findings []Finding
+}
+
+// Errors retourne les problèmes de gravité erreur
+func (r *ValidationReport) Errors() []Finding {
+	return r.filter(SeverityError)
+}
+
+// Warnings retourne les avertissements
+func (r *ValidationReport) Warnings() []Finding {
+	return r.filter(SeverityWarning)
+}
+
+// OK indique qu'aucune erreur n'a été relevée (les avertissements sont tolérés)
+func (r *ValidationReport) OK() bool {
+	return len(r.Errors()) == 0
+}
+
+// ForArtist retourne les problèmes concernant un artiste (toutes entités confondues)
+func (r *ValidationReport) ForArtist(id int) []Finding {
+	var findings []Finding
+	for _, f := range r.Findings {
+		if f.ID == id {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// Summary retourne un résumé court ("8 artistes, 2 erreurs, 5 avertissements")
+func (r *ValidationReport) Summary() string {
+	return fmt.Sprintf("%d artistes, %d erreur(s), %d avertissement(s)",
+		r.Artists, len(r.Errors()), len(r.Warnings()))
+}
+
+// String retourne le rapport complet, un problème par ligne
+func (r *ValidationReport) String() string {
+	var b strings.Builder
+	b.WriteString(r.Summary())
+	b.WriteString("\n")
+	for _, f := range r.Findings {
+		b.WriteString("  ")
+		b.WriteString(f.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (r *ValidationReport) filter(severity Severity) []Finding {
+	var findings []Finding
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+func (r *ValidationReport) add(severity Severity, entity string, id int, field, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, Finding{
+		Severity: severity,
+		Entity:   entity,
+		ID:       id,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate contrôle la cohérence d'un jeu de données et retourne le rapport
+func Validate(data *models.APIData) *ValidationReport {
+	report := &ValidationReport{}
+	if data == nil {
+		return report
+	}
+	report.Artists = len(data.Artists)
+
+	for _, artist := range data.Artists {
+		validateArtist(report, artist)
+	}
+
+	catalog := NewCatalog(data)
+	for _, issue := range catalog.Issues() {
+		severity := SeverityError
+		if issue.Kind == IssueMisaligned {
+			severity = SeverityWarning
+		}
+		report.add(severity, issueEntity(issue.Endpoint), issue.ID, "", "%s", issue)
+	}
+
+	for _, entry := range catalog.Entries() {
+		if entry.Date != nil {
+			validateDates(report, entry.Date)
+		}
+		if entry.Relation != nil {
+			validateRelation(report, entry.Relation)
+		}
+		validateConsistency(report, entry)
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		if report.Findings[i].ID != report.Findings[j].ID {
+			return report.Findings[i].ID < report.Findings[j].ID
+		}
+		return report.Findings[i].Severity > report.Findings[j].Severity
+	})
+
+	return report
+}
+
+// issueEntity convertit un nom d'endpoint du catalogue en nom d'entité
+func issueEntity(endpoint string) string {
+	switch endpoint {
+	case "artists":
+		return "artist"
+	case "locations":
+		return "location"
+	case "dates":
+		return "date"
+	default:
+		return endpoint
+	}
+}
+
+// validateArtist contrôle les champs d'un artiste
+func validateArtist(report *ValidationReport, artist models.Artist) {
+	if strings.TrimSpace(artist.Name) == "" {
+		report.add(SeverityError, "artist", artist.ID, "name", "nom vide")
+	}
+
+	if len(artist.Members) == 0 {
+		report.add(SeverityWarning, "artist", artist.ID, "members", "aucun membre")
+	}
+	for i, member := range artist.Members {
+		if strings.TrimSpace(member) == "" {
+			report.add(SeverityWarning, "artist", artist.ID, "members", "membre %d vide", i+1)
+		}
+	}
+
+	if artist.Image == "" {
+		report.add(SeverityWarning, "artist", artist.ID, "image", "aucune image")
+	}
+
+	if artist.CreationDate <= 0 || artist.CreationDate > time.Now().Year() {
+		report.add(SeverityWarning, "artist", artist.ID, "creationDate", "année de création improbable: %d", artist.CreationDate)
+	}
+
+	album, err := time.Parse(models.DateLayout, artist.FirstAlbum)
+	if err != nil {
+		report.add(SeverityError, "artist", artist.ID, "firstAlbum", "date illisible %q (format attendu JJ-MM-AAAA)", artist.FirstAlbum)
+		return
+	}
+	if artist.CreationDate > 0 && album.Year() < artist.CreationDate {
+		report.add(SeverityWarning, "artist", artist.ID, "firstAlbum",
+			"premier album (%d) antérieur à la création (%d)", album.Year(), artist.CreationDate)
+	}
+}
+
+// validateDates contrôle les dates de l'endpoint /dates
+func validateDates(report *ValidationReport, date *models.Date) {
+	starred := 0
+	for _, raw := range date.Dates {
+		parsed, err := models.ParseConcertDate(raw)
+		if parsed.Marked {
+			starred++
+		}
+		if err != nil {
+			report.add(SeverityError, "date", date.ID, "dates", "date illisible %q", raw)
+		}
+	}
+	if starred > 0 {
+		report.add(SeverityWarning, "date", date.ID, "dates", "%d date(s) préfixée(s) d'un astérisque", starred)
+	}
+}
+
+// validateRelation contrôle les dates de l'endpoint /relation
+func validateRelation(report *ValidationReport, relation *models.Relation) {
+	for _, location := range sortedKeys(relation.DatesLocations) {
+		dates := relation.DatesLocations[location]
+		if len(dates) == 0 {
+			report.add(SeverityWarning, "relation", relation.ID, location, "lieu sans date")
+		}
+		for _, raw := range dates {
+			parsed, err := models.ParseConcertDate(raw)
+			if err != nil {
+				report.add(SeverityError, "relation", relation.ID, location, "date illisible %q", raw)
+				continue
+			}
+			if parsed.Marked {
+				report.add(SeverityWarning, "relation", relation.ID, location, "date %q contient un astérisque", raw)
+			}
+		}
+	}
+}
+
+// validateConsistency compare /locations et /dates avec /relation pour un artiste
+func validateConsistency(report *ValidationReport, entry *CatalogEntry) {
+	if entry.Relation == nil {
+		return
+	}
+
+	if entry.Location != nil {
+		fromLocations := make(map[string]bool)
+		for _, location := range entry.Location.Locations {
+			fromLocations[location] = true
+		}
+		fromRelation := make(map[string]bool)
+		for location := range entry.Relation.DatesLocations {
+			fromRelation[location] = true
+		}
+
+		if missing := setDifference(fromLocations, fromRelation); len(missing) > 0 {
+			report.add(SeverityWarning, "location", entry.Artist.ID, "locations",
+				"absent(s) de /relation: %s", strings.Join(missing, ", "))
+		}
+		if missing := setDifference(fromRelation, fromLocations); len(missing) > 0 {
+			report.add(SeverityWarning, "location", entry.Artist.ID, "locations",
+				"absent(s) de /locations: %s", strings.Join(missing, ", "))
+		}
+	}
+
+	if entry.Date != nil {
+		fromDates := make(map[string]bool)
+		for _, date := range entry.Date.Dates {
+			fromDates[strings.TrimLeft(date, "*")] = true
+		}
+		fromRelation := make(map[string]bool)
+		for _, dates := range entry.Relation.DatesLocations {
+			for _, date := range dates {
+				fromRelation[date] = true
+			}
+		}
+
+		if missing := setDifference(fromDates, fromRelation); len(missing) > 0 {
+			report.add(SeverityWarning, "date", entry.Artist.ID, "dates",
+				"absente(s) de /relation: %s", strings.Join(missing, ", "))
+		}
+		if missing := setDifference(fromRelation, fromDates); len(missing) > 0 {
+			report.add(SeverityWarning, "date", entry.Artist.ID, "dates",
+				"absente(s) de /dates: %s", strings.Join(missing, ", "))
+		}
+	}
+}
+
+// setDifference retourne les clés de a absentes de b, triées
+func setDifference(a, b map[string]bool) []string {
+	var missing []string
+	for key := range a {
+		if !b[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// sortedKeys retourne les clés d'une relation triées
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
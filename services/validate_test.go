@@ -0,0 +1,99 @@
+package services
+
+import (
+	"groupie-tracker/fakeapi"
+	"groupie-tracker/models"
+	"testing"
+)
+
+// validArtist retourne un artiste sans problème, modifié par edit
+func validArtist(edit func(*models.Artist)) models.Artist {
+	artist := models.Artist{
+		ID:           1,
+		Name:         "Queen",
+		Image:        "https://example.com/queen.jpeg",
+		Members:      []string{"Freddie Mercury"},
+		CreationDate: 1970,
+		FirstAlbum:   "14-12-1973",
+	}
+	if edit != nil {
+		edit(&artist)
+	}
+	return artist
+}
+
+func TestValidate(t *testing.T) {
+	relation := models.Relation{ID: 1, DatesLocations: map[string][]string{"london-uk": {"14-07-1986"}}}
+
+	tests := []struct {
+		name     string
+		data     *models.APIData
+		severity Severity
+		entity   string
+		field    string
+	}{
+		{"nom vide", &models.APIData{
+			Artists:   []models.Artist{validArtist(func(a *models.Artist) { a.Name = " " })},
+			Relations: []models.Relation{relation},
+		}, SeverityError, "artist", "name"},
+		{"album illisible", &models.APIData{
+			Artists:   []models.Artist{validArtist(func(a *models.Artist) { a.FirstAlbum = "1973" })},
+			Relations: []models.Relation{relation},
+		}, SeverityError, "artist", "firstAlbum"},
+		{"album avant la création", &models.APIData{
+			Artists:   []models.Artist{validArtist(func(a *models.Artist) { a.CreationDate = 1980 })},
+			Relations: []models.Relation{relation},
+		}, SeverityWarning, "artist", "firstAlbum"},
+		{"aucun membre", &models.APIData{
+			Artists:   []models.Artist{validArtist(func(a *models.Artist) { a.Members = nil })},
+			Relations: []models.Relation{relation},
+		}, SeverityWarning, "artist", "members"},
+		{"date de relation illisible", &models.APIData{
+			Artists:   []models.Artist{validArtist(nil)},
+			Relations: []models.Relation{{ID: 1, DatesLocations: map[string][]string{"london-uk": {"bientôt"}}}},
+		}, SeverityError, "relation", "london-uk"},
+		{"astérisque", &models.APIData{
+			Artists:   []models.Artist{validArtist(nil)},
+			Relations: []models.Relation{relation},
+			Dates:     []models.Date{{ID: 1, Dates: []string{"*14-07-1986"}}},
+		}, SeverityWarning, "date", "dates"},
+		{"lieu absent de /relation", &models.APIData{
+			Artists:   []models.Artist{validArtist(nil)},
+			Relations: []models.Relation{relation},
+			Locations: []models.Location{{ID: 1, Locations: []string{"london-uk", "paris-france"}}},
+		}, SeverityWarning, "location", "locations"},
+		{"relation orpheline", &models.APIData{
+			Artists:   []models.Artist{validArtist(nil)},
+			Relations: []models.Relation{relation, {ID: 9}},
+		}, SeverityError, "relation", ""},
+		{"relation manquante", &models.APIData{
+			Artists: []models.Artist{validArtist(nil)},
+		}, SeverityError, "relation", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Validate(tt.data)
+			if len(report.Findings) != 1 {
+				t.Fatalf("Validate() = %d problème(s), attendu 1:\n%s", len(report.Findings), report)
+			}
+			f := report.Findings[0]
+			if f.Severity != tt.severity || f.Entity != tt.entity || f.Field != tt.field {
+				t.Errorf("Validate() = %s, attendu %v %s (%s)", f, tt.severity, tt.entity, tt.field)
+			}
+			if report.OK() != (tt.severity != SeverityError) {
+				t.Errorf("OK() = %v pour %s", report.OK(), f)
+			}
+		})
+	}
+}
+
+func TestValidateFixtures(t *testing.T) {
+	report := Validate(fakeapi.Fixtures())
+	if errs := report.Errors(); len(errs) > 0 {
+		t.Errorf("les données du faux serveur contiennent des erreurs:\n%s", report)
+	}
+	if report.Artists == 0 {
+		t.Errorf("Validate() n'a compté aucun artiste")
+	}
+}
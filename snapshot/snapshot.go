@@ -0,0 +1,127 @@
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"groupie-tracker/internal/fsutil"
+	"groupie-tracker/models"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SchemaVersion est la version actuelle du format d'instantané
+const SchemaVersion = 1
+
+// ErrUnsupportedVersion est retournée pour un instantané d'une version inconnue
+var ErrUnsupportedVersion = errors.New("version d'instantané non supportée")
+
+// Metadata décrit l'origine d'un instantané
+type Metadata struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	SourceURL     string    `json:"sourceURL"`
+	FetchedAt     time.Time `json:"fetchedAt"`
+}
+
+// Snapshot contient un jeu de données complet et ses métadonnées
+type Snapshot struct {
+	Metadata Metadata        `json:"metadata"`
+	Data     *models.APIData `json:"data"`
+}
+
+// New crée un instantané des données récupérées depuis sourceURL
+func New(data *models.APIData, sourceURL string) *Snapshot {
+	return &Snapshot{
+		Metadata: Metadata{
+			SchemaVersion: SchemaVersion,
+			SourceURL:     sourceURL,
+			FetchedAt:     time.Now().UTC(),
+		},
+		Data: data,
+	}
+}
+
+// Write écrit l'instantané en JSON, compressé en gzip si demandé
+func Write(w io.Writer, snap *Snapshot, compress bool) error {
+	if compress {
+		gz := gzip.NewWriter(w)
+		if err := writeJSON(gz, snap); err != nil {
+			gz.Close()
+			return err
+		}
+		return gz.Close()
+	}
+	return writeJSON(w, snap)
+}
+
+func writeJSON(w io.Writer, snap *Snapshot) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(snap); err != nil {
+		return fmt.Errorf("erreur lors de l'écriture de l'instantané: %w", err)
+	}
+	return nil
+}
+
+// WriteFile écrit l'instantané dans un fichier (compressé si l'extension est .gz).
+// L'écriture passe par un fichier temporaire renommé à la fin : un instantané
+// existant n'est jamais laissé à moitié écrit.
+func WriteFile(path string, snap *Snapshot) error {
+	err := fsutil.WriteAtomic(path, func(w io.Writer) error {
+		return Write(w, snap, IsCompressedPath(path))
+	})
+	if err != nil {
+		return fmt.Errorf("erreur lors de l'écriture de %s: %w", path, err)
+	}
+	return nil
+}
+
+// IsCompressedPath indique si un chemin désigne un instantané compressé
+func IsCompressedPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".gz")
+}
+
+// Read lit un instantané JSON, compressé en gzip ou non (détection automatique)
+func Read(r io.Reader) (*Snapshot, error) {
+	buffered := bufio.NewReader(r)
+
+	var reader io.Reader = buffered
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("erreur lors de la décompression de l'instantané: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	var snap Snapshot
+	if err := json.NewDecoder(reader).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("erreur lors du parsing de l'instantané: %w", err)
+	}
+
+	if snap.Metadata.SchemaVersion < 1 || snap.Metadata.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("%w: %d (attendue: %d)", ErrUnsupportedVersion, snap.Metadata.SchemaVersion, SchemaVersion)
+	}
+	if snap.Data == nil {
+		return nil, errors.New("instantané sans données")
+	}
+
+	return &snap, nil
+}
+
+// ReadFile lit un instantané depuis un fichier
+func ReadFile(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'ouverture de %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return Read(file)
+}
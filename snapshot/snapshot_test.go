@@ -0,0 +1,92 @@
+package snapshot
+
+import (
+	"errors"
+	"groupie-tracker/models"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteFileRoundTrip(t *testing.T) {
+	data := &models.APIData{
+		Artists:   []models.Artist{{ID: 1, Name: "Queen", Members: []string{"Freddie Mercury", "Brian May"}}},
+		Locations: []models.Location{{ID: 1, Locations: []string{"london-uk"}}},
+		Dates:     []models.Date{{ID: 1, Dates: []string{"*01-01-2020"}}},
+		Relations: []models.Relation{{ID: 1, DatesLocations: map[string][]string{"london-uk": {"01-01-2020"}}}},
+	}
+
+	tests := []struct {
+		name       string
+		file       string
+		compressed bool
+	}{
+		{"json", "snapshot.json", false},
+		{"gzip", "snapshot.json.gz", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, tt.file)
+			// Un ancien instantané est remplacé entièrement
+			if err := os.WriteFile(path, []byte(strings.Repeat("x", 1<<16)), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			snap := New(data, "http://localhost/api")
+			if err := WriteFile(path, snap); err != nil {
+				t.Fatalf("WriteFile(): %v", err)
+			}
+
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gzipped := len(raw) > 2 && raw[0] == 0x1f && raw[1] == 0x8b; gzipped != tt.compressed {
+				t.Errorf("fichier compressé = %v, attendu %v", gzipped, tt.compressed)
+			}
+
+			got, err := ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile(): %v", err)
+			}
+			if !reflect.DeepEqual(got.Data, data) {
+				t.Errorf("données relues = %+v, attendu %+v", got.Data, data)
+			}
+			if !got.Metadata.FetchedAt.Equal(snap.Metadata.FetchedAt) || got.Metadata.SourceURL != snap.Metadata.SourceURL {
+				t.Errorf("métadonnées relues = %+v, attendu %+v", got.Metadata, snap.Metadata)
+			}
+
+			// Aucun fichier temporaire ne reste après le renommage
+			if files, _ := filepath.Glob(filepath.Join(dir, "*")); len(files) != 1 {
+				t.Errorf("fichiers = %v, attendu seulement %s", files, tt.file)
+			}
+		})
+	}
+}
+
+func TestWriteFileMissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "absent", "snapshot.json")
+	if err := WriteFile(path, New(&models.APIData{}, "")); err == nil {
+		t.Errorf("WriteFile(%q) sans erreur, attendu une erreur", path)
+	}
+}
+
+func TestReadRejectsUnsupportedVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"version future", `{"metadata": {"schemaVersion": 99}, "data": {}}`},
+		{"version absente", `{"data": {}}`},
+	}
+
+	for _, tt := range tests {
+		if _, err := Read(strings.NewReader(tt.content)); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("%s: Read() = %v, attendu %v", tt.name, err, ErrUnsupportedVersion)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/api"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ShowDiagnostics affiche les statistiques des requêtes envoyées à l'API
+func ShowDiagnostics(window fyne.Window, metrics *api.Metrics) {
+	content := container.NewVBox()
+
+	fill := func() {
+		content.Objects = []fyne.CanvasObject{
+			widget.NewLabelWithStyle("🩺 Diagnostics de l'API", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+			widget.NewSeparator(),
+		}
+
+		stats := metrics.Snapshot()
+		if len(stats) == 0 {
+			content.Add(widget.NewLabel("Aucune requête envoyée à l'API"))
+		}
+
+		for _, endpoint := range stats {
+			content.Add(widget.NewLabelWithStyle("🔗 "+endpoint.Endpoint, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+			content.Add(widget.NewLabel(fmt.Sprintf("  📨 Requêtes: %d | ⏱️ Latence moyenne: %s | 📦 Reçu: %s",
+				endpoint.Requests, endpoint.AverageLatency().Round(1e6), formatBytes(endpoint.Bytes))))
+
+			if endpoint.ErrorCount() == 0 {
+				content.Add(widget.NewLabel("  ✅ Aucune erreur"))
+			} else {
+				content.Add(widget.NewLabel(fmt.Sprintf("  ❌ Erreurs: %d (%s)", endpoint.ErrorCount(), formatErrors(endpoint.Errors))))
+			}
+			content.Add(widget.NewSeparator())
+		}
+
+		content.Refresh()
+	}
+	fill()
+
+	refreshBtn := widget.NewButton("🔄 Actualiser", fill)
+	closeBtn := widget.NewButton("Fermer", func() {})
+
+	scroll := container.NewVScroll(content)
+	scroll.SetMinSize(fyne.NewSize(600, 400))
+
+	dialogContent := container.NewBorder(
+		nil,
+		container.NewCenter(container.NewHBox(refreshBtn, closeBtn)),
+		nil, nil,
+		scroll,
+	)
+
+	dialog := widget.NewModalPopUp(dialogContent, window.Canvas())
+
+	closeBtn.OnTapped = func() {
+		dialog.Hide()
+	}
+
+	dialog.Show()
+}
+
+// formatErrors liste les erreurs par catégorie ("network: 2, http: 1")
+func formatErrors(errors map[string]int64) string {
+	categories := make([]string, 0, len(errors))
+	for category := range errors {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	parts := make([]string, len(categories))
+	for i, category := range categories {
+		parts[i] = fmt.Sprintf("%s: %d", category, errors[category])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatBytes formate une taille en octets lisible ("1.2 Mo")
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f Mo", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f Ko", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d octets", n)
+	}
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"groupie-tracker/models"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// highlightedResult affiche un résultat de recherche avec son icône et les passages trouvés en gras
+func highlightedResult(result models.SearchResult) *widget.RichText {
+	segments := []widget.RichTextSegment{
+		&widget.TextSegment{Text: getTypeIcon(result.Kind) + " - ", Style: widget.RichTextStyleInline},
+	}
+
+	pos := 0
+	for _, span := range result.Matches {
+		if span.Start < pos || span.End > len(result.Value) {
+			continue
+		}
+		if span.Start > pos {
+			segments = append(segments, &widget.TextSegment{Text: result.Value[pos:span.Start], Style: widget.RichTextStyleInline})
+		}
+		segments = append(segments, &widget.TextSegment{Text: result.Value[span.Start:span.End], Style: widget.RichTextStyleStrong})
+		pos = span.End
+	}
+	if pos < len(result.Value) {
+		segments = append(segments, &widget.TextSegment{Text: result.Value[pos:], Style: widget.RichTextStyleInline})
+	}
+
+	return widget.NewRichText(segments...)
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+)
+
+// ImageLoader charge de manière asynchrone la miniature d'une image d'artiste
+type ImageLoader func(url string, done func(fyne.Resource, error))
+
+// artworkSize est la taille de la vignette affichée sur les cartes
+const artworkSize = 96
+
+// artistArtwork crée une vignette de taille fixe, complétée dès que l'image est chargée
+func artistArtwork(load ImageLoader, url string) fyne.CanvasObject {
+	img := canvas.NewImageFromResource(theme.MediaMusicIcon())
+	img.FillMode = canvas.ImageFillContain
+	img.SetMinSize(fyne.NewSize(artworkSize, artworkSize))
+
+	if load == nil || url == "" {
+		return img
+	}
+
+	load(url, func(res fyne.Resource, err error) {
+		// En cas d'erreur, l'icône par défaut reste affichée
+		if err != nil {
+			return
+		}
+		fyne.Do(func() {
+			img.Resource = res
+			img.Refresh()
+		})
+	})
+
+	return img
+}
@@ -2,9 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"groupie-tracker/geo"
 	"groupie-tracker/models"
 	"groupie-tracker/services"
-	"strings"
+	"sort"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -17,6 +18,7 @@ type MapView struct {
 	window        fyne.Window
 	searchService *services.SearchService
 	data          *models.APIData
+	geocoder      *geo.Geocoder
 }
 
 // NewMapView crée une nouvelle vue Carte
@@ -25,9 +27,15 @@ func NewMapView(window fyne.Window, searchService *services.SearchService, data
 		window:        window,
 		searchService: searchService,
 		data:          data,
+		geocoder:      geo.NewGeocoder(),
 	}
 }
 
+// SetGeocoder partage le service de géocodage de l'application (et son rapport des lieux inconnus)
+func (v *MapView) SetGeocoder(geocoder *geo.Geocoder) {
+	v.geocoder = geocoder
+}
+
 // Render affiche la vue Carte
 func (v *MapView) Render() *fyne.Container {
 	header := widget.NewLabelWithStyle("🗺️ Carte des Concerts", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
@@ -60,7 +68,7 @@ func (v *MapView) Render() *fyne.Container {
 			// Filtrage par lieu si nécessaire
 			var filteredConcerts []models.Concert
 			for _, concert := range concerts {
-				if locationFilter == "" || services.FormatLocation(concert.Location) == locationFilter {
+				if locationFilter == "" || concert.Place.String() == locationFilter {
 					filteredConcerts = append(filteredConcerts, concert)
 				}
 			}
@@ -80,15 +88,18 @@ func (v *MapView) Render() *fyne.Container {
 			concertList.Add(artistHeader)
 
 			// Grouper par lieu
-			locationMap := make(map[string][]string)
+			locationMap := make(map[string][]models.ConcertDate)
+			places := make(map[string]models.Place)
 			for _, concert := range filteredConcerts {
-				formattedLocation := services.FormatLocation(concert.Location)
-				locationMap[formattedLocation] = append(locationMap[formattedLocation], concert.Dates...)
+				formattedLocation := concert.Place.String()
+				locationMap[formattedLocation] = append(locationMap[formattedLocation], concert.ParsedDates()...)
+				places[formattedLocation] = concert.Place
 			}
 
 			// Afficher les concerts par lieu
 			for location, dates := range locationMap {
-				locationCard := v.createConcertCard(artist.Name, location, dates)
+				models.SortConcertDates(dates)
+				locationCard := v.createConcertCard(artist, places[location], dates)
 				concertList.Add(locationCard)
 			}
 
@@ -129,7 +140,7 @@ func (v *MapView) Render() *fyne.Container {
 func (v *MapView) createFilters() *fyne.Container {
 	// Bouton pour afficher tous les concerts
 	allConcertsBtn := widget.NewButton("🌍 Tous les concerts", func() {
-		// Action déjà gérée par la recherche vide
+		v.showAgenda()
 	})
 
 	// Bouton pour voir les statistiques
@@ -141,9 +152,9 @@ func (v *MapView) createFilters() *fyne.Container {
 }
 
 // createConcertCard crée une carte pour un concert
-func (v *MapView) createConcertCard(artistName, location string, dates []string) *fyne.Container {
+func (v *MapView) createConcertCard(artist models.Artist, place models.Place, dates []models.ConcertDate) *fyne.Container {
 	locationLabel := widget.NewLabelWithStyle(
-		fmt.Sprintf("📍 %s", location),
+		fmt.Sprintf("📍 %s", place),
 		fyne.TextAlignLeading,
 		fyne.TextStyle{Bold: true},
 	)
@@ -154,9 +165,9 @@ func (v *MapView) createConcertCard(artistName, location string, dates []string)
 		datesContainer.Add(dateLabel)
 	}
 
-	// Bouton pour voir sur la carte (simulation)
+	// Bouton pour voir la position du lieu
 	viewMapBtn := widget.NewButton("🗺️ Voir sur la carte", func() {
-		v.showLocationOnMap(location, artistName, dates)
+		v.showLocationOnMap(place, artist, dates)
 	})
 
 	card := container.NewVBox(
@@ -168,16 +179,16 @@ func (v *MapView) createConcertCard(artistName, location string, dates []string)
 	return container.NewPadded(card)
 }
 
-// showLocationOnMap simule l'affichage sur une carte
-func (v *MapView) showLocationOnMap(location, artistName string, dates []string) {
+// showLocationOnMap affiche la position d'un lieu et sa distance aux autres concerts de l'artiste
+func (v *MapView) showLocationOnMap(place models.Place, artist models.Artist, dates []models.ConcertDate) {
 	content := container.NewVBox(
 		widget.NewLabelWithStyle(
-			fmt.Sprintf("📍 %s", location),
+			fmt.Sprintf("📍 %s", place),
 			fyne.TextAlignCenter,
 			fyne.TextStyle{Bold: true},
 		),
 		widget.NewSeparator(),
-		widget.NewLabel(fmt.Sprintf("🎸 Artiste: %s", artistName)),
+		widget.NewLabel(fmt.Sprintf("🎸 Artiste: %s", artist.Name)),
 		widget.NewLabel(fmt.Sprintf("📅 Nombre de concerts: %d", len(dates))),
 		widget.NewSeparator(),
 	)
@@ -189,13 +200,20 @@ func (v *MapView) showLocationOnMap(location, artistName string, dates []string)
 		content.Add(widget.NewLabel(fmt.Sprintf("  • %s", date)))
 	}
 
-	// Informations géographiques (simulation)
+	// Informations géographiques (dictionnaire embarqué, sans accès réseau)
 	content.Add(widget.NewSeparator())
-	content.Add(widget.NewLabel("🌐 Coordonnées géographiques (exemple):"))
-	content.Add(widget.NewLabel("   Latitude: 48.8566"))
-	content.Add(widget.NewLabel("   Longitude: 2.3522"))
-	content.Add(widget.NewLabel(""))
-	content.Add(widget.NewLabel("💡 Une vraie carte interactive pourrait être intégrée ici"))
+	location, ok := v.geocoder.GeocodePlace(place)
+	if !ok {
+		content.Add(widget.NewLabel("🌐 Coordonnées géographiques inconnues pour ce lieu"))
+	} else {
+		content.Add(widget.NewLabel(fmt.Sprintf("🌐 Coordonnées géographiques (précision: %s):", location.Precision)))
+		content.Add(widget.NewLabel(fmt.Sprintf("   Latitude: %.4f", location.Lat)))
+		content.Add(widget.NewLabel(fmt.Sprintf("   Longitude: %.4f", location.Lon)))
+		if location.Approximate() {
+			content.Add(widget.NewLabel("   ⚠️ Position approximative"))
+		}
+		v.addNearbyConcerts(content, location, artist.ID)
+	}
 
 	closeBtn := widget.NewButton("Fermer", func() {})
 
@@ -218,6 +236,77 @@ func (v *MapView) showLocationOnMap(location, artistName string, dates []string)
 	dialog.Show()
 }
 
+// addNearbyConcerts liste les autres lieux de concert de l'artiste, du plus proche au plus lointain
+func (v *MapView) addNearbyConcerts(content *fyne.Container, origin geo.Location, artistID int) {
+	type nearby struct {
+		place    models.Place
+		distance float64
+	}
+
+	var others []nearby
+	for _, concert := range v.searchService.GetConcertsByArtistID(artistID) {
+		if concert.Place.Key == origin.Place.Key {
+			continue
+		}
+		location, ok := v.geocoder.GeocodePlace(concert.Place)
+		if !ok {
+			continue
+		}
+		others = append(others, nearby{place: concert.Place, distance: geo.Distance(origin.Coordinates, location.Coordinates)})
+	}
+	if len(others) == 0 {
+		return
+	}
+
+	sort.Slice(others, func(i, j int) bool {
+		return others[i].distance < others[j].distance
+	})
+
+	content.Add(widget.NewSeparator())
+	content.Add(widget.NewLabelWithStyle("Autres concerts de l'artiste:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	for _, other := range others {
+		content.Add(widget.NewLabel(fmt.Sprintf("  • %s — %.0f km", other.place, other.distance)))
+	}
+}
+
+// showAgenda affiche tous les concerts, un par date, dans l'ordre chronologique
+func (v *MapView) showAgenda() {
+	if v.data == nil {
+		return
+	}
+
+	events := v.searchService.Events(services.ByDate)
+
+	content := container.NewVBox(
+		widget.NewLabelWithStyle(
+			fmt.Sprintf("🌍 Tous les concerts (%d)", len(events)),
+			fyne.TextAlignCenter,
+			fyne.TextStyle{Bold: true},
+		),
+		widget.NewSeparator(),
+	)
+
+	for _, event := range events {
+		content.Add(widget.NewLabel(fmt.Sprintf("📅 %s — %s — 📍 %s", event.Date, event.ArtistName, event.Place)))
+	}
+
+	closeBtn := widget.NewButton("Fermer", func() {})
+
+	scroll := container.NewVScroll(content)
+	scroll.SetMinSize(fyne.NewSize(600, 500))
+
+	dialog := widget.NewModalPopUp(
+		container.NewBorder(nil, container.NewCenter(closeBtn), nil, nil, scroll),
+		v.window.Canvas(),
+	)
+
+	closeBtn.OnTapped = func() {
+		dialog.Hide()
+	}
+
+	dialog.Show()
+}
+
 // showStats affiche les statistiques des concerts
 func (v *MapView) showStats() {
 	if v.data == nil {
@@ -225,42 +314,23 @@ func (v *MapView) showStats() {
 	}
 
 	// Calculer les statistiques
-	totalArtists := len(v.data.Artists)
-	totalConcerts := 0
-	locationMap := make(map[string]int)
-	countryMap := make(map[string]int)
-
-	for _, relation := range v.data.Relations {
-		for location, dates := range relation.DatesLocations {
-			totalConcerts += len(dates)
-			locationMap[location]++
-
-			// Extraire le pays (dernier élément après split)
-			parts := strings.Split(location, "-")
-			if len(parts) > 0 {
-				country := parts[len(parts)-1]
-				countryMap[country]++
-			}
-		}
-	}
+	stats := v.searchService.Stats()
 
 	content := container.NewVBox(
 		widget.NewLabelWithStyle("📊 Statistiques des Concerts", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 		widget.NewSeparator(),
-		widget.NewLabel(fmt.Sprintf("🎸 Nombre d'artistes: %d", totalArtists)),
-		widget.NewLabel(fmt.Sprintf("🎤 Nombre total de concerts: %d", totalConcerts)),
-		widget.NewLabel(fmt.Sprintf("📍 Nombre de lieux différents: %d", len(locationMap))),
-		widget.NewLabel(fmt.Sprintf("🌍 Nombre de pays: %d", len(countryMap))),
+		widget.NewLabel(fmt.Sprintf("🎸 Nombre d'artistes: %d", stats.Artists)),
+		widget.NewLabel(fmt.Sprintf("🎤 Nombre total de concerts: %d", stats.Concerts)),
+		widget.NewLabel(fmt.Sprintf("📍 Nombre de lieux différents: %d", stats.Places)),
+		widget.NewLabel(fmt.Sprintf("🌍 Nombre de pays: %d", len(stats.Countries))),
 		widget.NewSeparator(),
 	)
 
 	// Top 5 des pays avec le plus de concerts
 	content.Add(widget.NewLabelWithStyle("🏆 Top 5 des pays:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
 
-	// Trier les pays par nombre de concerts (simple)
-	topCountries := getTopCountries(countryMap, 5)
-	for i, country := range topCountries {
-		content.Add(widget.NewLabel(fmt.Sprintf("  %d. %s - %d concerts", i+1, country.name, country.count)))
+	for i, country := range stats.TopCountries(5) {
+		content.Add(widget.NewLabel(fmt.Sprintf("  %d. %s - %d concerts", i+1, country.Name, country.Concerts)))
 	}
 
 	closeBtn := widget.NewButton("Fermer", func() {})
@@ -283,31 +353,3 @@ func (v *MapView) showStats() {
 
 	dialog.Show()
 }
-
-type countryCount struct {
-	name  string
-	count int
-}
-
-func getTopCountries(countryMap map[string]int, n int) []countryCount {
-	var countries []countryCount
-	for name, count := range countryMap {
-		countries = append(countries, countryCount{name, count})
-	}
-
-	// Tri simple par insertion
-	for i := 1; i < len(countries); i++ {
-		key := countries[i]
-		j := i - 1
-		for j >= 0 && countries[j].count < key.count {
-			countries[j+1] = countries[j]
-			j--
-		}
-		countries[j+1] = key
-	}
-
-	if len(countries) > n {
-		return countries[:n]
-	}
-	return countries
-}
@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"groupie-tracker/services"
+	"strings"
+)
+
+// otherBands retourne les autres groupes d'un membre (" — aussi dans Queen, Wham!"), vide s'il n'y en a pas
+func otherBands(searchService *services.SearchService, member string, artistID int) string {
+	var names []string
+	for _, artist := range searchService.BandsOf(member) {
+		if artist.ID != artistID {
+			names = append(names, artist.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return " — aussi dans " + strings.Join(names, ", ")
+}
@@ -0,0 +1,23 @@
+package ui
+
+import "groupie-tracker/overrides"
+
+// overrideMark retourne une marque si la valeur vient des surcharges locales
+func overrideMark(provenance *overrides.Provenance, artistID int, field string) string {
+	if provenance.Overridden(artistID, field) {
+		return " ✏️"
+	}
+	return ""
+}
+
+// artistMark retourne une marque si l'artiste a été ajouté ou corrigé localement
+func artistMark(provenance *overrides.Provenance, artistID int) string {
+	switch {
+	case provenance.Added(artistID):
+		return " 🆕"
+	case len(provenance.Fields(artistID)) > 0:
+		return " ✏️"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"groupie-tracker/models"
+	"groupie-tracker/services"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ShowQualityReport affiche le rapport de qualité des données, groupé par artiste
+func ShowQualityReport(window fyne.Window, data *models.APIData) {
+	report := services.Validate(data)
+
+	content := container.NewVBox(
+		widget.NewLabelWithStyle("🩺 Qualité des données", fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewSeparator(),
+		widget.NewLabel(fmt.Sprintf("🎸 Artistes contrôlés: %d", report.Artists)),
+		widget.NewLabel(fmt.Sprintf("❌ Erreurs: %d", len(report.Errors()))),
+		widget.NewLabel(fmt.Sprintf("⚠️ Avertissements: %d", len(report.Warnings()))),
+		widget.NewSeparator(),
+	)
+
+	if len(report.Findings) == 0 {
+		content.Add(widget.NewLabel("✅ Aucun problème détecté"))
+	}
+
+	// Les problèmes sont triés par ID : un titre par artiste
+	names := make(map[int]string)
+	if data != nil {
+		for _, artist := range data.Artists {
+			names[artist.ID] = artist.Name
+		}
+	}
+
+	lastID := -1
+	for _, finding := range report.Findings {
+		if finding.ID != lastID {
+			title := fmt.Sprintf("#%d", finding.ID)
+			if name, ok := names[finding.ID]; ok {
+				title = fmt.Sprintf("#%d %s", finding.ID, name)
+			}
+			content.Add(widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+			lastID = finding.ID
+		}
+
+		label := widget.NewLabel("  " + finding.String())
+		label.Wrapping = fyne.TextWrapWord
+		content.Add(label)
+	}
+
+	closeBtn := widget.NewButton("Fermer", func() {})
+
+	scroll := container.NewVScroll(content)
+	scroll.SetMinSize(fyne.NewSize(600, 450))
+
+	dialogContent := container.NewBorder(
+		nil,
+		container.NewCenter(closeBtn),
+		nil, nil,
+		scroll,
+	)
+
+	dialog := widget.NewModalPopUp(dialogContent, window.Canvas())
+
+	closeBtn.OnTapped = func() {
+		dialog.Hide()
+	}
+
+	dialog.Show()
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"groupie-tracker/api"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ConcertRefresher recharge depuis la source les concerts d'un seul artiste
+type ConcertRefresher func(artistID int) error
+
+// button crée le bouton "Actualiser" d'une liste de concerts.
+// Le rechargement se fait en arrière-plan ; la liste est ensuite redessinée par fill,
+// ou précédée d'un message si l'actualisation a échoué.
+func (refresh ConcertRefresher) button(artistID int, content *fyne.Container, fill func()) *widget.Button {
+	var refreshBtn *widget.Button
+	refreshBtn = widget.NewButton("🔄 Actualiser", func() {
+		refreshBtn.Disable()
+		go func() {
+			err := refresh(artistID)
+			fyne.Do(func() {
+				refreshBtn.Enable()
+				if err != nil {
+					content.Objects = append([]fyne.CanvasObject{
+						widget.NewLabel("⚠️ Actualisation impossible: " + api.Describe(err)),
+					}, content.Objects...)
+					content.Refresh()
+					return
+				}
+				fill()
+			})
+		}()
+	})
+	return refreshBtn
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"groupie-tracker/models"
+	"groupie-tracker/overrides"
 	"groupie-tracker/services"
 	"math/rand"
 	"strings"
@@ -20,6 +21,10 @@ type ShazamView struct {
 	searchService *services.SearchService
 	data          *models.APIData
 	history       []ShazamResult
+
+	refreshConcerts ConcertRefresher
+	loadImage       ImageLoader
+	provenance      *overrides.Provenance
 }
 
 // ShazamResult représente un résultat de reconnaissance
@@ -190,7 +195,7 @@ func (v *ShazamView) createHistoryCard(result ShazamResult) *fyne.Container {
 	timeAgo := v.formatTimeAgo(result.Timestamp)
 
 	nameLabel := widget.NewLabelWithStyle(
-		fmt.Sprintf("🎵 %s", result.Artist.Name),
+		fmt.Sprintf("🎵 %s%s", result.Artist.Name, artistMark(v.provenance, result.Artist.ID)),
 		fyne.TextAlignLeading,
 		fyne.TextStyle{Bold: true},
 	)
@@ -207,10 +212,14 @@ func (v *ShazamView) createHistoryCard(result ShazamResult) *fyne.Container {
 
 	buttonsContainer := container.NewHBox(detailsBtn, concertsBtn)
 
-	card := container.NewVBox(
+	details := container.NewVBox(
 		nameLabel,
 		infoLabel,
 		buttonsContainer,
+	)
+
+	card := container.NewVBox(
+		container.NewBorder(nil, nil, artistArtwork(v.loadImage, result.Artist.Image), nil, details),
 		widget.NewSeparator(),
 	)
 
@@ -247,19 +256,19 @@ func (v *ShazamView) formatTimeAgo(t time.Time) string {
 // showArtistDetails affiche les détails d'un artiste
 func (v *ShazamView) showArtistDetails(artist models.Artist) {
 	content := container.NewVBox(
-		widget.NewLabelWithStyle(artist.Name, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(artist.Name+overrideMark(v.provenance, artist.ID, overrides.FieldName), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 		widget.NewSeparator(),
-		widget.NewLabel(fmt.Sprintf("🎸 Année de création: %d", artist.CreationDate)),
-		widget.NewLabel(fmt.Sprintf("💿 Premier album: %s", artist.FirstAlbum)),
+		widget.NewLabel(fmt.Sprintf("🎸 Année de création: %d%s", artist.CreationDate, overrideMark(v.provenance, artist.ID, overrides.FieldCreationDate))),
+		widget.NewLabel(fmt.Sprintf("💿 Premier album: %s%s", artist.FirstAlbum, overrideMark(v.provenance, artist.ID, overrides.FieldFirstAlbum))),
 		widget.NewLabel(fmt.Sprintf("👥 Nombre de membres: %d", len(artist.Members))),
 		widget.NewSeparator(),
 	)
 
-	membersLabel := widget.NewLabelWithStyle("Membres:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	membersLabel := widget.NewLabelWithStyle("Membres"+overrideMark(v.provenance, artist.ID, overrides.FieldMembers)+":", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
 	content.Add(membersLabel)
 
 	for i, member := range artist.Members {
-		content.Add(widget.NewLabel(fmt.Sprintf("  %d. %s", i+1, member)))
+		content.Add(widget.NewLabel(fmt.Sprintf("  %d. %s%s", i+1, member, otherBands(v.searchService, member, artist.ID))))
 	}
 
 	closeBtn := widget.NewButton("Fermer", func() {})
@@ -279,41 +288,66 @@ func (v *ShazamView) showArtistDetails(artist models.Artist) {
 	dialog.Show()
 }
 
+// SetProvenance permet de marquer les valeurs corrigées localement
+func (v *ShazamView) SetProvenance(provenance *overrides.Provenance) {
+	v.provenance = provenance
+}
+
+// SetImageLoader active l'affichage des images des artistes sur les cartes
+func (v *ShazamView) SetImageLoader(loader ImageLoader) {
+	v.loadImage = loader
+}
+
+// SetConcertRefresher active le bouton d'actualisation des concerts d'un artiste
+func (v *ShazamView) SetConcertRefresher(refresher ConcertRefresher) {
+	v.refreshConcerts = refresher
+}
+
 // showConcerts affiche les concerts d'un artiste
 func (v *ShazamView) showConcerts(artist models.Artist) {
-	concerts := v.searchService.GetConcertsByArtistID(artist.ID)
-
 	concertContent := container.NewVBox()
 
-	if len(concerts) == 0 {
-		concertContent.Add(widget.NewLabel("❌ Aucun concert programmé"))
-	} else {
-		for _, concert := range concerts {
-			locationLabel := widget.NewLabelWithStyle(
-				fmt.Sprintf("📍 %s", services.FormatLocation(concert.Location)),
-				fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
-			concertContent.Add(locationLabel)
-
-			for _, date := range concert.Dates {
-				dateLabel := widget.NewLabel(fmt.Sprintf("  📅 %s", date))
-				concertContent.Add(dateLabel)
+	fillConcerts := func() {
+		concertContent.Objects = nil
+		concerts := v.searchService.GetConcertsByArtistID(artist.ID)
+
+		if len(concerts) == 0 {
+			concertContent.Add(widget.NewLabel("❌ Aucun concert programmé"))
+		} else {
+			for _, concert := range concerts {
+				locationLabel := widget.NewLabelWithStyle(
+					fmt.Sprintf("📍 %s", concert.Place.String()),
+					fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+				concertContent.Add(locationLabel)
+
+				for _, date := range concert.ParsedDates() {
+					dateLabel := widget.NewLabel(fmt.Sprintf("  📅 %s", date))
+					concertContent.Add(dateLabel)
+				}
+				concertContent.Add(widget.NewSeparator())
 			}
-			concertContent.Add(widget.NewSeparator())
 		}
+		concertContent.Refresh()
 	}
+	fillConcerts()
 
 	closeBtn := widget.NewButton("Fermer", func() {})
+	buttons := container.NewHBox(closeBtn)
+
+	if v.refreshConcerts != nil {
+		buttons = container.NewHBox(v.refreshConcerts.button(artist.ID, concertContent, fillConcerts), closeBtn)
+	}
 
 	scroll := container.NewVScroll(concertContent)
 	scroll.SetMinSize(fyne.NewSize(500, 400))
 
 	dialogContent := container.NewBorder(
 		container.NewVBox(
-			widget.NewLabelWithStyle(fmt.Sprintf("🎤 Concerts de %s", artist.Name),
+			widget.NewLabelWithStyle(fmt.Sprintf("🎤 Concerts de %s%s", artist.Name, overrideMark(v.provenance, artist.ID, overrides.FieldConcerts)),
 				fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 			widget.NewSeparator(),
 		),
-		container.NewCenter(closeBtn),
+		container.NewCenter(buttons),
 		nil, nil,
 		scroll,
 	)
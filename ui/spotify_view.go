@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"groupie-tracker/models"
+	"groupie-tracker/overrides"
 	"groupie-tracker/services"
 	"strings"
 	"time"
@@ -17,6 +18,10 @@ type SpotifyView struct {
 	window        fyne.Window
 	searchService *services.SearchService
 	data          *models.APIData
+
+	refreshConcerts ConcertRefresher
+	loadImage       ImageLoader
+	provenance      *overrides.Provenance
 }
 
 // NewSpotifyView crée une nouvelle vue Spotify
@@ -87,18 +92,16 @@ func (v *SpotifyView) Render() *fyne.Container {
 				results = results[:maxResults]
 			}
 
+			// Les résultats arrivent triés par pertinence
 			for _, result := range results {
 				r := result // Capture pour la closure
-				suggestionBtn := widget.NewButton(
-					fmt.Sprintf("%s - %s", getTypeIcon(r.Type), r.Value),
-					func() {
-						if r.Artist != nil {
-							v.showArtistDetails(*r.Artist)
-						}
-					},
-				)
-				suggestionBtn.Alignment = widget.ButtonAlignLeading
-				suggestionsBox.Add(suggestionBtn)
+				suggestionBtn := widget.NewButton("", func() {
+					if r.Artist != nil {
+						v.showArtistDetails(*r.Artist)
+					}
+				})
+				// Le texte surligné est posé sur le bouton, qui reste cliquable
+				suggestionsBox.Add(container.NewStack(suggestionBtn, highlightedResult(r)))
 			}
 			suggestionsScroll.Show()
 		} else {
@@ -133,15 +136,17 @@ func (v *SpotifyView) Render() *fyne.Container {
 
 // createArtistCard crée une carte pour un artiste
 func (v *SpotifyView) createArtistCard(artist models.Artist) *fyne.Container {
-	nameLabel := widget.NewLabelWithStyle(artist.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	nameLabel := widget.NewLabelWithStyle(artist.Name+artistMark(v.provenance, artist.ID), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
 	nameLabel.TextStyle.Bold = true
 
-	membersText := "👥 Membres: " + strings.Join(artist.Members, ", ")
+	membersText := "👥 Membres" + overrideMark(v.provenance, artist.ID, overrides.FieldMembers) + ": " + strings.Join(artist.Members, ", ")
 	membersLabel := widget.NewLabel(membersText)
 	membersLabel.Wrapping = fyne.TextWrapWord
 
-	infoLabel := widget.NewLabel(fmt.Sprintf("📅 Création: %d | 💿 Premier album: %s | 🎸 Membres: %d",
-		artist.CreationDate, artist.FirstAlbum, len(artist.Members)))
+	infoLabel := widget.NewLabel(fmt.Sprintf("📅 Création: %d%s | 💿 Premier album: %s%s | 🎸 Membres: %d",
+		artist.CreationDate, overrideMark(v.provenance, artist.ID, overrides.FieldCreationDate),
+		artist.FirstAlbum, overrideMark(v.provenance, artist.ID, overrides.FieldFirstAlbum),
+		len(artist.Members)))
 
 	// Bouton pour voir les détails
 	detailsBtn := widget.NewButton("📋 Détails", func() {
@@ -154,11 +159,15 @@ func (v *SpotifyView) createArtistCard(artist models.Artist) *fyne.Container {
 
 	buttonsContainer := container.NewHBox(detailsBtn, concertBtn)
 
-	card := container.NewVBox(
+	details := container.NewVBox(
 		nameLabel,
 		membersLabel,
 		infoLabel,
 		buttonsContainer,
+	)
+
+	card := container.NewVBox(
+		container.NewBorder(nil, nil, artistArtwork(v.loadImage, artist.Image), nil, details),
 		widget.NewSeparator(),
 	)
 
@@ -168,18 +177,18 @@ func (v *SpotifyView) createArtistCard(artist models.Artist) *fyne.Container {
 // showArtistDetails affiche les détails d'un artiste
 func (v *SpotifyView) showArtistDetails(artist models.Artist) {
 	content := container.NewVBox(
-		widget.NewLabelWithStyle(artist.Name, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(artist.Name+overrideMark(v.provenance, artist.ID, overrides.FieldName), fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 		widget.NewSeparator(),
-		widget.NewLabel(fmt.Sprintf("🎸 Année de création: %d", artist.CreationDate)),
-		widget.NewLabel(fmt.Sprintf("💿 Premier album: %s", artist.FirstAlbum)),
+		widget.NewLabel(fmt.Sprintf("🎸 Année de création: %d%s", artist.CreationDate, overrideMark(v.provenance, artist.ID, overrides.FieldCreationDate))),
+		widget.NewLabel(fmt.Sprintf("💿 Premier album: %s%s", artist.FirstAlbum, overrideMark(v.provenance, artist.ID, overrides.FieldFirstAlbum))),
 		widget.NewLabel(fmt.Sprintf("👥 Nombre de membres: %d", len(artist.Members))),
 	)
 
-	membersLabel := widget.NewLabelWithStyle("Membres:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	membersLabel := widget.NewLabelWithStyle("Membres"+overrideMark(v.provenance, artist.ID, overrides.FieldMembers)+":", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
 	content.Add(membersLabel)
 
 	for i, member := range artist.Members {
-		content.Add(widget.NewLabel(fmt.Sprintf("  %d. %s", i+1, member)))
+		content.Add(widget.NewLabel(fmt.Sprintf("  %d. %s%s", i+1, member, otherBands(v.searchService, member, artist.ID))))
 	}
 
 	closeBtn := widget.NewButton("Fermer", func() {})
@@ -200,41 +209,66 @@ func (v *SpotifyView) showArtistDetails(artist models.Artist) {
 	dialog.Show()
 }
 
+// SetProvenance permet de marquer les valeurs corrigées localement
+func (v *SpotifyView) SetProvenance(provenance *overrides.Provenance) {
+	v.provenance = provenance
+}
+
+// SetImageLoader active l'affichage des images des artistes sur les cartes
+func (v *SpotifyView) SetImageLoader(loader ImageLoader) {
+	v.loadImage = loader
+}
+
+// SetConcertRefresher active le bouton d'actualisation des concerts d'un artiste
+func (v *SpotifyView) SetConcertRefresher(refresher ConcertRefresher) {
+	v.refreshConcerts = refresher
+}
+
 // showConcerts affiche les concerts d'un artiste
 func (v *SpotifyView) showConcerts(artist models.Artist) {
-	concerts := v.searchService.GetConcertsByArtistID(artist.ID)
-
 	concertContent := container.NewVBox()
 
-	if len(concerts) == 0 {
-		concertContent.Add(widget.NewLabel("❌ Aucun concert programmé"))
-	} else {
-		for _, concert := range concerts {
-			locationLabel := widget.NewLabelWithStyle(
-				fmt.Sprintf("📍 %s", services.FormatLocation(concert.Location)),
-				fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
-			concertContent.Add(locationLabel)
-
-			for _, date := range concert.Dates {
-				dateLabel := widget.NewLabel(fmt.Sprintf("  📅 %s", date))
-				concertContent.Add(dateLabel)
+	fillConcerts := func() {
+		concertContent.Objects = nil
+		concerts := v.searchService.GetConcertsByArtistID(artist.ID)
+
+		if len(concerts) == 0 {
+			concertContent.Add(widget.NewLabel("❌ Aucun concert programmé"))
+		} else {
+			for _, concert := range concerts {
+				locationLabel := widget.NewLabelWithStyle(
+					fmt.Sprintf("📍 %s", concert.Place.String()),
+					fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+				concertContent.Add(locationLabel)
+
+				for _, date := range concert.ParsedDates() {
+					dateLabel := widget.NewLabel(fmt.Sprintf("  📅 %s", date))
+					concertContent.Add(dateLabel)
+				}
+				concertContent.Add(widget.NewSeparator())
 			}
-			concertContent.Add(widget.NewSeparator())
 		}
+		concertContent.Refresh()
 	}
+	fillConcerts()
 
 	closeBtn := widget.NewButton("Fermer", func() {})
+	buttons := container.NewHBox(closeBtn)
+
+	if v.refreshConcerts != nil {
+		buttons = container.NewHBox(v.refreshConcerts.button(artist.ID, concertContent, fillConcerts), closeBtn)
+	}
 
 	scroll := container.NewVScroll(concertContent)
 	scroll.SetMinSize(fyne.NewSize(500, 400))
 
 	dialogContent := container.NewBorder(
 		container.NewVBox(
-			widget.NewLabelWithStyle(fmt.Sprintf("🎤 Concerts de %s", artist.Name),
+			widget.NewLabelWithStyle(fmt.Sprintf("🎤 Concerts de %s%s", artist.Name, overrideMark(v.provenance, artist.ID, overrides.FieldConcerts)),
 				fyne.TextAlignCenter, fyne.TextStyle{Bold: true}),
 			widget.NewSeparator(),
 		),
-		container.NewCenter(closeBtn),
+		container.NewCenter(buttons),
 		nil, nil,
 		scroll,
 	)
@@ -249,18 +283,16 @@ func (v *SpotifyView) showConcerts(artist models.Artist) {
 }
 
 // getTypeIcon retourne l'icône pour un type de résultat
-func getTypeIcon(resultType string) string {
-	switch resultType {
-	case "artist":
+func getTypeIcon(kind models.ResultKind) string {
+	switch kind {
+	case models.KindArtist:
 		return "🎸"
-	case "member":
+	case models.KindMember:
 		return "👤"
-	case "location":
+	case models.KindLocation:
 		return "📍"
-	case "album":
+	case models.KindAlbum:
 		return "💿"
-	case "date":
-		return "📅"
 	default:
 		return "🔍"
 	}